@@ -0,0 +1,33 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Branch continues with thenChain when pred holds for the current value and
+// with elseChain otherwise. Failed and canceled chains skip both branches.
+func Branch[T, U any](c *Chain[T],
+	pred func(context.Context, T) bool,
+	thenChain func(*Chain[T]) *Chain[U],
+	elseChain func(*Chain[T]) *Chain[U]) *Chain[U] {
+
+	if !c.result.IsSuccess() {
+		return step(c, pred, func() rop.Result[U] {
+			return offTrack[T, U](c.result)
+		})
+	}
+
+	if pred(c.ctx, c.result.Result()) {
+		return thenChain(c)
+	}
+	return elseChain(c)
+}
+
+func offTrack[T, U any](r rop.Result[T]) rop.Result[U] {
+	if r.IsCancel() {
+		return rop.Cancel[U](r.Err())
+	}
+	return rop.Fail[U](r.Err())
+}
@@ -11,6 +11,12 @@ import (
 type Chain[T any] struct {
 	ctx    context.Context
 	result rop.Result[T]
+	// retry re-executes the ThenTry step that produced result (nil otherwise)
+	retry *retried[T]
+	// observers are notified after every step (see Observe)
+	observers []func(context.Context, StepEvent)
+	// name is the name of the next step (see Named)
+	name string
 }
 
 // Start creates a new chain from a rop.Result
@@ -37,41 +43,126 @@ func (c *Chain[T]) Result() rop.Result[T] {
 // Then chains a function that returns rop.Result[U]
 func Then[T, U any](c *Chain[T],
 	onSuccess func(context.Context, T) rop.Result[U]) *Chain[U] {
-	return &Chain[U]{
-		ctx:    c.ctx,
-		result: solo.Switch[T, U](c.ctx, c.result, onSuccess),
-	}
+	return then(c, onSuccess, onSuccess)
+}
+
+// then is Then naming the step after fn
+func then[T, U any](c *Chain[T], fn any,
+	onSuccess func(context.Context, T) rop.Result[U]) *Chain[U] {
+	return step(c, fn, func() rop.Result[U] {
+		return solo.Switch[T, U](c.ctx, c.result, onSuccess)
+	})
 }
 
 // ThenTry chains a function that returns (U, error)
 func ThenTry[T, U any](c *Chain[T],
 	tryOnSuccess func(context.Context, T) (U, error)) *Chain[U] {
-	return &Chain[U]{
-		ctx:    c.ctx,
-		result: solo.Try[T, U](c.ctx, c.result, tryOnSuccess),
+	return thenTry(c, tryOnSuccess, tryOnSuccess)
+}
+
+// thenTry is ThenTry naming the step after fn
+func thenTry[T, U any](c *Chain[T], fn any,
+	tryOnSuccess func(context.Context, T) (U, error)) *Chain[U] {
+	input := c.result
+	run := func() rop.Result[U] {
+		return solo.Try[T, U](c.ctx, input, tryOnSuccess)
 	}
+
+	next := step(c, fn, run)
+	next.retry = &retried[U]{name: c.stepName(fn), input: outcomeOf(input), run: run}
+	return next
 }
 
 // Map chains a pure transformation function
 func Map[T, U any](c *Chain[T],
 	onSuccess func(context.Context, T) U) *Chain[U] {
-	return &Chain[U]{
-		ctx:    c.ctx,
-		result: solo.Map[T, U](c.ctx, c.result, onSuccess),
-	}
+	return step(c, onSuccess, func() rop.Result[U] {
+		return solo.Map[T, U](c.ctx, c.result, onSuccess)
+	})
+}
+
+// Validate fails the chain with errMsg when validate reports an invalid value
+func (c *Chain[T]) Validate(validate func(context.Context, T) (bool, string)) *Chain[T] {
+	return step(c, validate, func() rop.Result[T] {
+		return solo.AndValidate[T](c.ctx, c.result, validate)
+	})
 }
 
 // Ensure performs a side effect without changing the result
 func (c *Chain[T]) Ensure(onSuccess func(context.Context, T)) *Chain[T] {
-	return &Chain[T]{
-		ctx: c.ctx,
-		result: solo.Tee[T](c.ctx, c.result,
+	return step(c, onSuccess, func() rop.Result[T] {
+		return solo.Tee[T](c.ctx, c.result,
 			func(ctx context.Context, result rop.Result[T]) {
 				if result.IsSuccess() {
 					onSuccess(ctx, result.Result())
 				}
-			}),
+			})
+	})
+}
+
+// TryTee performs a side effect that may fail; its error becomes the chain failure
+func (c *Chain[T]) TryTee(sideEffect func(context.Context, T) error) *Chain[T] {
+	return step(c, sideEffect, func() rop.Result[T] {
+		return solo.TryTee[T](c.ctx, c.result, sideEffect)
+	})
+}
+
+// DoubleEnsure performs side effects for success, failure and cancel without changing the result.
+// Nil callbacks are skipped.
+func (c *Chain[T]) DoubleEnsure(onSuccess func(context.Context, T),
+	onFailure func(context.Context, error),
+	onCancel func(context.Context, error)) *Chain[T] {
+	return c.doubleEnsure("DoubleEnsure", onSuccess, onFailure, onCancel)
+}
+
+// doubleEnsure is DoubleEnsure naming the step after the first callback set
+// (fn when there is none); the step is skipped when the outcome has no callback.
+func (c *Chain[T]) doubleEnsure(fn any, onSuccess func(context.Context, T),
+	onFailure func(context.Context, error),
+	onCancel func(context.Context, error)) *Chain[T] {
+
+	switch {
+	case onSuccess != nil:
+		fn = onSuccess
+	case onFailure != nil:
+		fn = onFailure
+	case onCancel != nil:
+		fn = onCancel
+	}
+
+	var skipped bool
+	switch {
+	case c.result.IsSuccess():
+		skipped = onSuccess == nil
+	case c.result.IsCancel():
+		skipped = onCancel == nil
+	default:
+		skipped = onFailure == nil
 	}
+
+	return derive(c, observed(c, fn, skipped, func() rop.Result[T] {
+		return solo.DoubleTee[T](c.ctx, c.result,
+			func(ctx context.Context, r T) {
+				if onSuccess != nil {
+					onSuccess(ctx, r)
+				}
+			},
+			func(ctx context.Context, err error) {
+				if onFailure != nil {
+					onFailure(ctx, err)
+				}
+			},
+			func(ctx context.Context, err error) {
+				if onCancel != nil {
+					onCancel(ctx, err)
+				}
+			})
+	}))
+}
+
+// TapError performs a side effect for a failed (not canceled) result without changing it
+func (c *Chain[T]) TapError(onFailure func(context.Context, error)) *Chain[T] {
+	return c.doubleEnsure("TapError", nil, onFailure, nil)
 }
 
 // Finally collapses the chain into a final result using solo.Finally
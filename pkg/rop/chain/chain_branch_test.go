@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func classify(c *Chain[int]) *Chain[string] {
+	return Branch(c,
+		func(ctx context.Context, v int) bool { return v%2 == 0 },
+		func(c *Chain[int]) *Chain[string] {
+			return Map(c, func(ctx context.Context, v int) string { return "even:" + strconv.Itoa(v) })
+		},
+		func(c *Chain[int]) *Chain[string] {
+			return Then(c, func(ctx context.Context, v int) rop.Result[string] {
+				return rop.Fail[string](errors.New("odd"))
+			})
+		})
+}
+
+func TestBranch_SelectsByPredicate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	even := classify(FromValue(ctx, 4)).Result()
+	if !even.IsSuccess() || even.Result() != "even:4" {
+		t.Fatalf("expected success 'even:4', got success=%v val=%v err=%v", even.IsSuccess(), even.Result(), even.Err())
+	}
+
+	odd := classify(FromValue(ctx, 3)).Result()
+	if odd.IsSuccess() || odd.Err().Error() != "odd" {
+		t.Fatalf("expected failure 'odd', got success=%v err=%v", odd.IsSuccess(), odd.Err())
+	}
+}
+
+func TestBranch_PropagatesCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := classify(Start(ctx, rop.Cancel[int](errors.New("stop")))).Result()
+	if !out.IsCancel() || out.Err().Error() != "stop" {
+		t.Fatalf("expected cancel 'stop', got cancel=%v err=%v", out.IsCancel(), out.Err())
+	}
+}
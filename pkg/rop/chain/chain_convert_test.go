@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/tiny"
+)
+
+type ctxKey struct{}
+
+func TestToChain_CarriesContextAndResult(t *testing.T) {
+	t.Parallel()
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req-1")
+
+	tc := tiny.FromValue(ctx, 2).Map(func(ctx context.Context, v int) int { return v * 3 })
+
+	var seen any
+	out := Map(ToChain(tc), func(ctx context.Context, v int) string {
+		seen = ctx.Value(ctxKey{})
+		return "v" + strconv.Itoa(v)
+	}).Result()
+
+	if !out.IsSuccess() || out.Result() != "v6" {
+		t.Fatalf("expected success v6, got: success=%v, val=%v, err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+	if seen != "req-1" {
+		t.Fatalf("expected ctx to be carried over, got %v", seen)
+	}
+}
+
+func TestToTiny_CarriesFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := Start(ctx, rop.Fail[int](errors.New("boom")))
+	tc := ToTiny(c)
+
+	if tc.Context() != ctx {
+		t.Fatalf("expected ctx to be carried over")
+	}
+	out := tc.Map(func(ctx context.Context, v int) int { return v + 1 }).Result()
+	if out.IsSuccess() || out.Err().Error() != "boom" {
+		t.Fatalf("expected failure 'boom', got: success=%v, err=%v", out.IsSuccess(), out.Err())
+	}
+}
@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestDoubleEnsure_DispatchesByOutcome(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var got []string
+	record := func(c *Chain[int]) *Chain[int] {
+		return c.DoubleEnsure(
+			func(ctx context.Context, v int) { got = append(got, "success") },
+			func(ctx context.Context, err error) { got = append(got, "failure:"+err.Error()) },
+			func(ctx context.Context, err error) { got = append(got, "cancel:"+err.Error()) })
+	}
+
+	out := record(FromValue(ctx, 1)).Result()
+	record(Start(ctx, rop.Fail[int](errors.New("f"))))
+	record(Start(ctx, rop.Cancel[int](errors.New("c"))))
+
+	if !out.IsSuccess() || out.Result() != 1 {
+		t.Fatalf("expected unchanged success 1, got success=%v val=%v", out.IsSuccess(), out.Result())
+	}
+	if len(got) != 3 || got[0] != "success" || got[1] != "failure:f" || got[2] != "cancel:c" {
+		t.Fatalf("unexpected side effects: %v", got)
+	}
+}
+
+func TestTapError_OnlyOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	tap := func(ctx context.Context, err error) { calls++ }
+
+	FromValue(ctx, 1).TapError(tap)
+	Start(ctx, rop.Cancel[int](errors.New("c"))).TapError(tap)
+	out := Start(ctx, rop.Fail[int](errors.New("f"))).TapError(tap).Result()
+
+	if calls != 1 {
+		t.Fatalf("expected TapError to fire once, got %d", calls)
+	}
+	if out.IsSuccess() || out.Err().Error() != "f" {
+		t.Fatalf("expected unchanged failure 'f', got %v", out.Err())
+	}
+}
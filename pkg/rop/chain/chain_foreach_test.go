@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func parse(c *Chain[string]) *Chain[int] {
+	return ThenTry(c, func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+}
+
+func TestForEach_AllItems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	results := ForEach(ctx, []string{"1", "x", "3"}, false, parse)
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if !results[0].IsSuccess() || results[1].IsSuccess() || results[2].Result() != 3 {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
+
+func TestForEach_FailFast(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	results := ForEach(ctx, []string{"1", "x", "3"}, true, parse)
+
+	if len(results) != 2 || results[1].IsSuccess() {
+		t.Fatalf("expected to stop after the failing item, got %d results", len(results))
+	}
+}
+
+func TestForEach_StopsOnCancelledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if results := ForEach(ctx, []string{"1", "2"}, false, parse); len(results) != 0 {
+		t.Fatalf("expected no results for a cancelled context, got %d", len(results))
+	}
+}
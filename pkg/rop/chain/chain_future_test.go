@@ -0,0 +1,95 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestThenAsync_RunsConcurrently(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := FromValue(ctx, 5)
+
+	slow := func(ctx context.Context, v int) rop.Result[int] {
+		time.Sleep(50 * time.Millisecond)
+		return rop.Success(v * 2)
+	}
+
+	start := time.Now()
+	f1 := ThenAsync(c, slow)
+	f2 := ThenAsync(c, slow)
+	r1, r2 := f1.Await().Result(), f2.Await().Result()
+
+	if elapsed := time.Since(start); elapsed > 90*time.Millisecond {
+		t.Fatalf("expected steps to run concurrently, took %v", elapsed)
+	}
+	if r1.Result() != 10 || r2.Result() != 10 {
+		t.Fatalf("expected 10 and 10, got %v and %v", r1.Result(), r2.Result())
+	}
+}
+
+func TestThenTryAsync_ErrorAndShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := ThenTryAsync(FromValue(ctx, 1), func(ctx context.Context, v int) (string, error) {
+		return "", errors.New("remote")
+	}).Await().Result()
+	if out.IsSuccess() || out.Err().Error() != "remote" {
+		t.Fatalf("expected failure 'remote', got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+
+	called := false
+	skipped := ThenAsync(Start(ctx, rop.Fail[int](errors.New("prev"))), func(ctx context.Context, v int) rop.Result[int] {
+		called = true
+		return rop.Success(v)
+	}).Await().Result()
+	if called || skipped.Err().Error() != "prev" {
+		t.Fatalf("expected step to be skipped, called=%v err=%v", called, skipped.Err())
+	}
+}
+
+func TestAwait_CancelledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	out := ThenAsync(FromValue(ctx, 1), func(ctx context.Context, v int) rop.Result[int] {
+		time.Sleep(200 * time.Millisecond)
+		return rop.Success(v)
+	}).Await().Result()
+
+	if !out.IsCancel() || !errors.Is(out.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected cancel on deadline, got cancel=%v err=%v", out.IsCancel(), out.Err())
+	}
+}
+
+func TestThenAsync_PanicGoesToHandler(t *testing.T) {
+	t.Parallel()
+
+	handled := make(chan *rop.PanicError, 1)
+	ctx := core.WithPanicHandler(context.Background(), func(p *rop.PanicError) { handled <- p })
+	ctx, group := core.WithGroup(ctx)
+
+	c := ThenAsync(FromValue(ctx, 1), func(ctx context.Context, v int) rop.Result[int] {
+		panic("async step")
+	}).Await()
+
+	var panicErr *rop.PanicError
+	if !errors.As(c.Result().Err(), &panicErr) || panicErr.Value != "async step" {
+		t.Fatalf("expected the panic as a failure, got %v", c.Result().Err())
+	}
+	if err := group.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if p := <-handled; p.Value != "async step" {
+		t.Fatalf("expected the panic handler called, got %v", p.Value)
+	}
+}
@@ -0,0 +1,33 @@
+package chain
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func TestTo_FluentTypeChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := To[string](FromValue(ctx, 7)).
+		Map(func(ctx context.Context, v int) string { return strconv.Itoa(v) }).
+		Validate(func(ctx context.Context, s string) (bool, string) { return s != "", "empty" })
+
+	out := To[user](To[int](s).Try(func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })).
+		Then(func(ctx context.Context, id int) rop.Result[user] {
+			return rop.Success(user{ID: id, Name: "u" + strconv.Itoa(id)})
+		}).
+		Result()
+
+	if !out.IsSuccess() || out.Result().ID != 7 || out.Result().Name != "u7" {
+		t.Fatalf("expected user 7, got success=%v val=%+v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func double(ctx context.Context, v int) int { return v * 2 }
+
+func TestWithLogger_LogsExecutedSteps(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ctx := context.Background()
+
+	c := Map(WithLogger(FromValue(ctx, 2), logger), double)
+	c = ThenTry(c, func(ctx context.Context, v int) (int, error) { return 0, errors.New("db down") })
+	Map(c, double) // skipped
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "level=DEBUG") || !strings.Contains(lines[0], "step=chain.double") ||
+		!strings.Contains(lines[0], "outcome=success") || !strings.Contains(lines[0], "duration=") {
+		t.Fatalf("unexpected success log line: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "level=ERROR") || !strings.Contains(lines[1], "outcome=failure") ||
+		!strings.Contains(lines[1], `error="db down"`) {
+		t.Fatalf("unexpected failure log line: %s", lines[1])
+	}
+}
+
+func TestWithLoggerLevels_CustomLevels(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	ctx := context.Background()
+
+	Map(WithLoggerLevels(FromValue(ctx, 1), logger,
+		LogLevels{Success: slog.LevelInfo, Failure: slog.LevelError, Cancel: slog.LevelWarn}), double)
+
+	if !strings.Contains(buf.String(), "level=INFO") {
+		t.Fatalf("expected success logged at INFO, got %q", buf.String())
+	}
+}
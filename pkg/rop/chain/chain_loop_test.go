@@ -0,0 +1,113 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestRepeatUntil_RepeatsWhileConditionHolds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	out := FromValue(ctx, 0).RepeatUntil(
+		func(ctx context.Context, v int) *Chain[int] {
+			calls++
+			return FromValue(ctx, v+1)
+		},
+		func(ctx context.Context, v int) bool { return v < 3 },
+	).Result()
+
+	if !out.IsSuccess() || out.Result() != 3 || calls != 3 {
+		t.Fatalf("expected 3 after 3 iterations, got val=%v calls=%d", out.Result(), calls)
+	}
+}
+
+func TestRepeatUntil_ExecutesOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	FromValue(ctx, 1).RepeatUntil(
+		func(ctx context.Context, v int) *Chain[int] {
+			calls++
+			return FromValue(ctx, v+1)
+		},
+		func(ctx context.Context, v int) bool { return false },
+	)
+
+	if calls != 1 {
+		t.Fatalf("expected a single iteration, got %d", calls)
+	}
+}
+
+func TestWhile_NoExecutionWhenConditionFalse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := FromValue(ctx, 10).While(
+		func(ctx context.Context, v int) *Chain[int] {
+			t.Fatalf("body must not run")
+			return FromValue(ctx, v)
+		},
+		func(ctx context.Context, v int) bool { return v < 3 },
+	).Result()
+
+	if out.Result() != 10 {
+		t.Fatalf("expected unchanged 10, got %v", out.Result())
+	}
+}
+
+func TestWhileChain_TypeChangingBody(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := WhileChain(FromValue(ctx, 1), func(c *Chain[int]) *Chain[int] {
+		s := Map(c, func(ctx context.Context, v int) string { return strconv.Itoa(v) + "0" })
+		return ThenTry(s, func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+	}, func(ctx context.Context, v int) bool { return v < 1000 }).Result()
+
+	if !out.IsSuccess() || out.Result() != 1000 {
+		t.Fatalf("expected 1000, got success=%v val=%v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
+
+func TestLoops_InnerFailureBreaksLoop(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	out := RepeatUntilChain(FromValue(ctx, 1), func(c *Chain[int]) *Chain[int] {
+		calls++
+		return Then(c, func(ctx context.Context, v int) rop.Result[int] {
+			return rop.Fail[int](errors.New("inner"))
+		})
+	}, func(ctx context.Context, v int) bool { return true }).Result()
+
+	if out.IsSuccess() || out.Err().Error() != "inner" || calls != 1 {
+		t.Fatalf("expected failure 'inner' after one iteration, got err=%v calls=%d", out.Err(), calls)
+	}
+}
+
+func TestLoops_StopOnCancelledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := FromValue(ctx, 0).While(
+		func(ctx context.Context, v int) *Chain[int] {
+			if v == 5 {
+				cancel()
+			}
+			return FromValue(ctx, v+1)
+		},
+		func(ctx context.Context, v int) bool { return true },
+	).Result()
+
+	if !out.IsCancel() {
+		t.Fatalf("expected cancel once the context is done, got success=%v val=%v", out.IsSuccess(), out.Result())
+	}
+}
@@ -0,0 +1,45 @@
+package chain
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func parsePipeline() Pipeline[string, string] {
+	p1 := PipeTry(Define[string](), func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) })
+	p2 := PipeMap(p1, func(ctx context.Context, n int) int { return n * 2 })
+	return PipeThen(p2, func(ctx context.Context, n int) rop.Result[string] {
+		return rop.Success("v" + strconv.Itoa(n))
+	})
+}
+
+func TestPipeline_RunManyInputs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	p := parsePipeline()
+
+	for in, want := range map[string]string{"1": "v2", "21": "v42"} {
+		out := p.RunValue(ctx, in)
+		if !out.IsSuccess() || out.Result() != want {
+			t.Fatalf("input %q: expected %q, got success=%v val=%v err=%v", in, want, out.IsSuccess(), out.Result(), out.Err())
+		}
+	}
+
+	if out := p.RunValue(ctx, "x"); out.IsSuccess() {
+		t.Fatalf("expected failure for non-numeric input")
+	}
+}
+
+func TestApply_ContinuesChain(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := Apply(FromValue(ctx, "5"), parsePipeline()).Result()
+	if !out.IsSuccess() || out.Result() != "v10" {
+		t.Fatalf("expected success 'v10', got success=%v val=%v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
@@ -0,0 +1,96 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+var errTransient = errors.New("transient")
+
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	attempts := 0
+	c := ThenTry(FromValue(ctx, 2), func(ctx context.Context, v int) (int, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, errTransient
+		}
+		return v * 10, nil
+	}).Retry(5, core.ConstantBackoff(time.Millisecond))
+
+	out := c.Result()
+	if !out.IsSuccess() || out.Result() != 20 {
+		t.Fatalf("expected success 20, got success=%v val=%v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_ExhaustsAttempts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	attempts := 0
+	c := ThenTry(FromValue(ctx, 1), func(ctx context.Context, v int) (int, error) {
+		attempts++
+		return 0, errTransient
+	}).Retry(2, nil)
+
+	if out := c.Result(); out.IsSuccess() || !errors.Is(out.Err(), errTransient) {
+		t.Fatalf("expected failure 'transient', got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 1 call + 2 retries, got %d", attempts)
+	}
+}
+
+func TestRetryIf_NonRetryableStopsImmediately(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	permanent := errors.New("permanent")
+	attempts := 0
+	c := ThenTry(FromValue(ctx, 1), func(ctx context.Context, v int) (int, error) {
+		attempts++
+		return 0, permanent
+	}).RetryIf(3, nil, func(err error) bool { return errors.Is(err, errTransient) })
+
+	if out := c.Result(); !errors.Is(out.Err(), permanent) {
+		t.Fatalf("expected permanent error, got %v", out.Err())
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retries for non-retryable error, got %d attempts", attempts)
+	}
+}
+
+func TestRetry_CancelledDuringBackoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	c := ThenTry(FromValue(ctx, 1), func(ctx context.Context, v int) (int, error) {
+		return 0, errTransient
+	}).Retry(3, core.ConstantBackoff(time.Second))
+
+	if out := c.Result(); !out.IsCancel() {
+		t.Fatalf("expected cancel while waiting for backoff, got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+}
+
+func TestRetry_WithoutThenTryIsNoop(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := Map(FromValue(ctx, 1), func(ctx context.Context, v int) int { return v + 1 }).Retry(3, nil)
+	if out := c.Result(); !out.IsSuccess() || out.Result() != 2 {
+		t.Fatalf("expected unchanged success 2, got success=%v val=%v", out.IsSuccess(), out.Result())
+	}
+}
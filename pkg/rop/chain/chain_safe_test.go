@@ -0,0 +1,51 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestSafeThen_RecoversPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := SafeThen(FromValue(ctx, 1), func(ctx context.Context, v int) rop.Result[int] {
+		panic("boom")
+	}).Result()
+
+	var pe *rop.PanicError
+	if out.IsSuccess() || !errors.As(out.Err(), &pe) {
+		t.Fatalf("expected panic failure, got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+	if pe.Value != "boom" || !strings.Contains(string(pe.Stack), "chain_safe_test.go") {
+		t.Fatalf("expected panic value and stack, got value=%v", pe.Value)
+	}
+}
+
+func TestSafeThenTry_RecoversErrorPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cause := errors.New("nil deref")
+	out := SafeThenTry(FromValue(ctx, 1), func(ctx context.Context, v int) (string, error) {
+		panic(cause)
+	}).Result()
+
+	if out.IsSuccess() || !errors.Is(out.Err(), cause) {
+		t.Fatalf("expected failure wrapping the panic error, got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+}
+
+func TestSafeMap_NoPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := SafeMap(FromValue(ctx, 2), func(ctx context.Context, v int) int { return v * 3 }).Result()
+	if !out.IsSuccess() || out.Result() != 6 {
+		t.Fatalf("expected success 6, got success=%v val=%v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestThenTryWithTimeout_CompletesInTime(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := ThenTryWithTimeout(FromValue(ctx, 2), 100*time.Millisecond,
+		func(ctx context.Context, v int) (string, error) { return "ok", nil }).Result()
+
+	if !out.IsSuccess() || out.Result() != "ok" {
+		t.Fatalf("expected success 'ok', got success=%v err=%v", out.IsSuccess(), out.Err())
+	}
+}
+
+func TestThenTryWithTimeout_CancelOnExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Now()
+	out := ThenTryWithTimeout(FromValue(ctx, 2), 10*time.Millisecond,
+		func(ctx context.Context, v int) (string, error) {
+			time.Sleep(time.Second) // ignores ctx on purpose
+			return "late", nil
+		}).Result()
+
+	if !out.IsCancel() || !errors.Is(out.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected cancel with deadline exceeded, got cancel=%v err=%v", out.IsCancel(), out.Err())
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("step should be abandoned at the deadline")
+	}
+}
+
+func TestThenTryWithTimeout_ErrorIsFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := ThenTryWithTimeout(FromValue(ctx, 2), time.Second,
+		func(ctx context.Context, v int) (string, error) { return "", errors.New("http 500") }).Result()
+
+	if out.IsSuccess() || out.IsCancel() || out.Err().Error() != "http 500" {
+		t.Fatalf("expected failure 'http 500', got cancel=%v err=%v", out.IsCancel(), out.Err())
+	}
+}
+
+func TestThenTryWithTimeout_AbandonedStepInGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx, group := core.WithGroup(context.Background())
+	release := make(chan struct{})
+
+	c := ThenTryWithTimeout(FromValue(ctx, 1), 5*time.Millisecond, func(ctx context.Context, v int) (int, error) {
+		<-release
+		return v, nil
+	})
+	if !c.Result().IsCancel() {
+		t.Fatalf("expected a cancel on timeout, got %v", c.Result().Err())
+	}
+	if err := group.AssertIdle(); err == nil {
+		t.Fatalf("expected the abandoned step to be owned by the group")
+	}
+
+	close(release)
+	if err := group.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,130 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func logFailure(context.Context, error) {}
+
+func TestTrace_RecordsStepStates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, recorder := Trace(FromValue(ctx, 3))
+
+	c = Map(c, double)
+	c = c.Validate(func(ctx context.Context, v int) (bool, string) { return v < 5, "too big" })
+	c = Map(c, double)
+
+	out := Finally(c,
+		func(ctx context.Context, v int) string { return "ok" },
+		func(ctx context.Context, err error) string { return "fail" },
+		func(ctx context.Context, err error) string { return "cancel" })
+	if out != "fail" {
+		t.Fatalf("expected 'fail', got %q", out)
+	}
+
+	steps := recorder.Steps()
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 recorded steps, got %d", len(steps))
+	}
+
+	if steps[0].Index != 0 || steps[0].Input != OutcomeSuccess || steps[0].Output != OutcomeSuccess ||
+		steps[0].Name != "chain.double" {
+		t.Fatalf("unexpected first step: %+v", steps[0])
+	}
+	if steps[1].Output != OutcomeFailure || steps[1].Err == nil || steps[1].Err.Error() != "too big" {
+		t.Fatalf("unexpected validation step: %+v", steps[1])
+	}
+	if !steps[2].Skipped || steps[2].Input != OutcomeFailure || steps[2].Output != OutcomeFailure {
+		t.Fatalf("expected last step skipped on failure, got %+v", steps[2])
+	}
+}
+
+func TestTrace_RecordsCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, recorder := Trace(FromValue(ctx, 1))
+	ThenTry(c, func(ctx context.Context, v int) (int, error) { return 0, context.Canceled })
+
+	steps := recorder.Steps()
+	if len(steps) != 1 || steps[0].Output != OutcomeCancel || !errors.Is(steps[0].Err, context.Canceled) {
+		t.Fatalf("expected a single cancel step, got %+v", steps)
+	}
+}
+
+func TestTrace_NamedSteps(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c, recorder := Trace(FromValue(ctx, 3))
+
+	c = Map(c.Named("double"), func(ctx context.Context, v int) int { return v * 2 })
+	c = SafeMap(c, double)
+	_ = Map(c, func(ctx context.Context, v int) int { return v + 1 })
+
+	steps := recorder.Steps()
+	if len(steps) != 3 {
+		t.Fatalf("expected 3 recorded steps, got %+v", steps)
+	}
+	if steps[0].Name != "double" {
+		t.Fatalf("expected the named step, got %q", steps[0].Name)
+	}
+	if steps[1].Name != "chain.double" {
+		t.Fatalf("expected SafeMap named after its function, got %q", steps[1].Name)
+	}
+	if steps[2].Name == "double" {
+		t.Fatalf("expected the name to apply to one step only")
+	}
+}
+
+func TestTrace_RecordsEveryStep(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	boom := errors.New("boom")
+	c, recorder := Trace(FromValue(ctx, 1))
+
+	c = ThenTry(c.Named("flaky"), func(ctx context.Context, v int) (int, error) { return 0, boom }).
+		Retry(2, nil).
+		TapError(logFailure).
+		DoubleEnsure(func(ctx context.Context, v int) {}, nil, nil)
+	c = Branch(c, func(ctx context.Context, v int) bool { return true },
+		func(c *Chain[int]) *Chain[int] { return c },
+		func(c *Chain[int]) *Chain[int] { return c })
+	c = Zip(c, FromValue(ctx, 2), func(ctx context.Context, a, b int) rop.Result[int] {
+		return rop.Success(a + b)
+	})
+	ThenAsync(c.Named("async"), func(ctx context.Context, v int) rop.Result[int] {
+		return rop.Success(v)
+	}).Await()
+
+	steps := recorder.Steps()
+	if len(steps) != 8 {
+		t.Fatalf("expected 8 recorded steps, got %+v", steps)
+	}
+	for i, attempt := range []int{0, 1, 2} {
+		if steps[i].Name != "flaky" || steps[i].Attempt != attempt || steps[i].Output != OutcomeFailure {
+			t.Fatalf("unexpected attempt %d: %+v", attempt, steps[i])
+		}
+	}
+	if steps[3].Name != "chain.logFailure" || steps[3].Skipped {
+		t.Fatalf("expected TapError to run on the failure, got %+v", steps[3])
+	}
+	if !steps[4].Skipped {
+		t.Fatalf("expected DoubleEnsure without a failure callback skipped, got %+v", steps[4])
+	}
+	for _, s := range steps[5:] {
+		if !s.Skipped || !errors.Is(s.Err, boom) {
+			t.Fatalf("expected off-track steps skipped with the failure, got %+v", s)
+		}
+	}
+	if steps[7].Name != "async" {
+		t.Fatalf("expected the awaited step named, got %q", steps[7].Name)
+	}
+}
@@ -0,0 +1,29 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTryTee_SuccessAndFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	emitted := 0
+
+	ok := FromValue(ctx, 4).TryTee(func(ctx context.Context, v int) error {
+		emitted = v
+		return nil
+	}).Result()
+	if !ok.IsSuccess() || ok.Result() != 4 || emitted != 4 {
+		t.Fatalf("expected unchanged success 4 and emitted event, got val=%v emitted=%d", ok.Result(), emitted)
+	}
+
+	bad := FromValue(ctx, 4).TryTee(func(ctx context.Context, v int) error {
+		return errors.New("emit failed")
+	}).Result()
+	if bad.IsSuccess() || bad.Err().Error() != "emit failed" {
+		t.Fatalf("expected failure 'emit failed', got success=%v err=%v", bad.IsSuccess(), bad.Err())
+	}
+}
@@ -0,0 +1,45 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func positive(ctx context.Context, v int) (bool, string) {
+	return v > 0, "must be positive"
+}
+
+func TestValidate_PassAndFail(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	ok := FromValue(ctx, 3).Validate(positive).Result()
+	if !ok.IsSuccess() || ok.Result() != 3 {
+		t.Fatalf("expected success 3, got success=%v err=%v", ok.IsSuccess(), ok.Err())
+	}
+
+	bad := FromValue(ctx, -1).Validate(positive).Result()
+	if bad.IsSuccess() || bad.Err().Error() != "must be positive" {
+		t.Fatalf("expected failure 'must be positive', got success=%v err=%v", bad.IsSuccess(), bad.Err())
+	}
+}
+
+func TestValidate_SkipsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+	out := Start(ctx, rop.Fail[int](errors.New("prev"))).
+		Validate(func(ctx context.Context, v int) (bool, string) {
+			called = true
+			return true, ""
+		}).Result()
+
+	if called || out.Err().Error() != "prev" {
+		t.Fatalf("expected validation to be skipped, called=%v err=%v", called, out.Err())
+	}
+}
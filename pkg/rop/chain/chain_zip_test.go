@@ -0,0 +1,45 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func concat(ctx context.Context, a int, b string) rop.Result[string] {
+	return rop.Success(strconv.Itoa(a) + b)
+}
+
+func TestZip_BothSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	out := Zip(FromValue(ctx, 1), FromValue(ctx, "a"), concat).Result()
+	if !out.IsSuccess() || out.Result() != "1a" {
+		t.Fatalf("expected success '1a', got success=%v val=%v err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
+
+func TestZip_FailurePrecedence(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	one := Zip(FromValue(ctx, 1), Start(ctx, rop.Fail[string](errors.New("b"))), concat).Result()
+	if one.IsSuccess() || one.IsCancel() || one.Err().Error() != "b" {
+		t.Fatalf("expected failure 'b', got cancel=%v err=%v", one.IsCancel(), one.Err())
+	}
+
+	both := Zip(Start(ctx, rop.Fail[int](errors.New("a"))), Start(ctx, rop.Fail[string](errors.New("b"))), concat).Result()
+	if errs := rop.GetErrors(both.Err()); len(errs) != 2 || errs[0].Error() != "a" {
+		t.Fatalf("expected joined errors [a b], got %v", both.Err())
+	}
+
+	canceled := Zip(Start(ctx, rop.Fail[int](errors.New("a"))), Start(ctx, rop.Cancel[string](errors.New("c"))), concat).Result()
+	if !canceled.IsCancel() {
+		t.Fatalf("expected cancel to take precedence, got err=%v", canceled.Err())
+	}
+}
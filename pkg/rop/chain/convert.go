@@ -0,0 +1,17 @@
+package chain
+
+import (
+	"github.com/ib-77/rop3/pkg/rop/tiny"
+)
+
+// ToChain continues a tiny chain with chain's API, carrying over its ctx and
+// current Result (joined failures for an accumulating tiny chain).
+func ToChain[T any](t tiny.Chain[T]) *Chain[T] {
+	return Start(t.Context(), t.Result())
+}
+
+// ToTiny hands the chain's ctx and current Result over to a tiny chain.
+// Observers and retry state are not carried over.
+func ToTiny[T any](c *Chain[T]) tiny.Chain[T] {
+	return tiny.Start(c.ctx, c.result)
+}
@@ -7,9 +7,27 @@
 //
 // Key operations:
 // - Start/FromValue: begin a chain from a Result[T] or value
+// - ToChain/ToTiny: hand a computation over from/to a tiny.Chain (ctx and Result carried over)
 // - Then: switch to a new Result[U] via a function
 // - ThenTry: call a function (U, error) and convert error to failure
+// - ThenTryWithTimeout: ThenTry under a per-step deadline (cancel on expiry)
+// - ThenAsync/ThenTryAsync + Await: run independent steps concurrently via a Future
+// - Retry/RetryIf: re-run the preceding ThenTry step with a backoff
+// - Validate: fail the chain when a value does not pass validation
+// - Branch: pick one of two sub-chains based on the current value
+// - To[U](c).Then/Try/Map: type-changing steps written as method calls
 // - Map: transform the successful value (T -> U)
 // - Ensure: run side effects on success without changing the result
+// - TryTee: run a side effect that may fail, failing the chain on error
+// - DoubleEnsure/TapError: run side effects on failure and cancel branches too
+// - ForEach: run the same chain over a slice of values
+// - SafeThen/SafeThenTry/SafeMap: recover panics in steps as failures
+// - Observe/WithLogger: report every step's name, outcome and duration
+// - Trace: record every step into an inspectable []StepTrace
+// - Named: name the next step for observers in place of its function name
+// - Define/PipeThen/PipeTry/PipeMap: build a reusable Pipeline[T,U] once and run it
+//   on many inputs, in a chain (Apply) or as a lite engine (lite.Step(p.Step()))
+// - Zip: join two independent chains into one
+// - RepeatUntil/While (and RepeatUntilChain/WhileChain): loops with tiny parity
 // - Finally: collapse the chain into a final value via handlers
 package chain
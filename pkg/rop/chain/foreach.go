@@ -0,0 +1,30 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// ForEach runs the chain built by build for every value, in order, and returns
+// the results. With failFast the loop stops after the first non-successful
+// result, so the returned slice may be shorter than values. The loop also stops
+// once ctx is done.
+func ForEach[T, U any](ctx context.Context, values []T, failFast bool,
+	build func(*Chain[T]) *Chain[U]) []rop.Result[U] {
+
+	results := make([]rop.Result[U], 0, len(values))
+	for _, v := range values {
+		if ctx.Err() != nil {
+			break
+		}
+
+		res := build(FromValue(ctx, v)).Result()
+		results = append(results, res)
+
+		if failFast && !res.IsSuccess() {
+			break
+		}
+	}
+	return results
+}
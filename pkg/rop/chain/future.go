@@ -0,0 +1,99 @@
+package chain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/solo"
+)
+
+// Future is the pending result of a chain step started with ThenAsync.
+type Future[T any] struct {
+	ctx       context.Context
+	done      chan struct{}
+	result    rop.Result[T]
+	observers []func(context.Context, StepEvent)
+	// event is the step reported to the observers on Await
+	event StepEvent
+	start time.Time
+	// duration is set once the step has finished
+	duration time.Duration
+}
+
+// ThenAsync starts onSuccess in its own goroutine and returns immediately, so
+// several independent steps can run concurrently and be awaited later.
+// Observers see the step once it is awaited.
+func ThenAsync[T, U any](c *Chain[T],
+	onSuccess func(context.Context, T) rop.Result[U]) *Future[U] {
+	return thenAsync(c, onSuccess, onSuccess)
+}
+
+// thenAsync is ThenAsync naming the step after fn
+func thenAsync[T, U any](c *Chain[T], fn any,
+	onSuccess func(context.Context, T) rop.Result[U]) *Future[U] {
+
+	f := &Future[U]{
+		ctx:       c.ctx,
+		done:      make(chan struct{}),
+		observers: c.observers,
+		event: StepEvent{
+			Name:    c.stepName(fn),
+			Input:   outcomeOf(c.result),
+			Skipped: !c.result.IsSuccess(),
+		},
+		start: time.Now(),
+	}
+
+	core.Go(c.ctx, func() {
+		defer close(f.done)
+		defer func() {
+			if p := recover(); p != nil {
+				// awaiting gets a failure, the panic handler of ctx the panic
+				f.result = rop.Fail[U](rop.NewPanicError(p))
+				f.duration = time.Since(f.start)
+				panic(p)
+			}
+		}()
+
+		f.result = solo.Switch[T, U](c.ctx, c.result, onSuccess)
+		f.duration = time.Since(f.start)
+	})
+
+	return f
+}
+
+// ThenTryAsync is ThenAsync for functions that return (U, error)
+func ThenTryAsync[T, U any](c *Chain[T],
+	tryOnSuccess func(context.Context, T) (U, error)) *Future[U] {
+	return thenAsync(c, tryOnSuccess, func(ctx context.Context, v T) rop.Result[U] {
+		return solo.Try[T, U](ctx, rop.Success(v), tryOnSuccess)
+	})
+}
+
+// Done is closed once the step has finished
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the step finishes and continues the chain with its result.
+// If the chain context is done first, the chain is canceled with its cause.
+func (f *Future[T]) Await() *Chain[T] {
+	var result rop.Result[T]
+	event := f.event
+
+	select {
+	case <-f.done:
+		result = f.result
+		event.Duration = f.duration
+	case <-f.ctx.Done():
+		result = rop.Cancel[T](context.Cause(f.ctx))
+		event.Duration = time.Since(f.start)
+	}
+
+	event.Output = outcomeOf(result)
+	event.Err = result.Err()
+	notify(f.ctx, f.observers, event)
+	return &Chain[T]{ctx: f.ctx, result: result, observers: f.observers}
+}
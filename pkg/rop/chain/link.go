@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Link is a pending T -> U type change of a chain. Go methods cannot introduce
+// type parameters, so To fixes U up front and the Link methods keep the call
+// fluent: chain.To[string](c).Map(format).Validate(nonEmpty)
+type Link[T, U any] struct {
+	c *Chain[T]
+}
+
+// To starts a type change of c to U (T is inferred from c)
+func To[U, T any](c *Chain[T]) Link[T, U] {
+	return Link[T, U]{c: c}
+}
+
+// Then continues with a function that returns rop.Result[U]
+func (l Link[T, U]) Then(onSuccess func(context.Context, T) rop.Result[U]) *Chain[U] {
+	return Then(l.c, onSuccess)
+}
+
+// Try continues with a function that returns (U, error)
+func (l Link[T, U]) Try(tryOnSuccess func(context.Context, T) (U, error)) *Chain[U] {
+	return ThenTry(l.c, tryOnSuccess)
+}
+
+// Map continues with a pure transformation to U
+func (l Link[T, U]) Map(onSuccess func(context.Context, T) U) *Chain[U] {
+	return Map(l.c, onSuccess)
+}
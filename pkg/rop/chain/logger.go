@@ -0,0 +1,52 @@
+package chain
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogLevels selects the slog level used for each step outcome
+type LogLevels struct {
+	Success slog.Level
+	Failure slog.Level
+	Cancel  slog.Level
+}
+
+var DefaultLogLevels = LogLevels{
+	Success: slog.LevelDebug,
+	Failure: slog.LevelError,
+	Cancel:  slog.LevelWarn,
+}
+
+// WithLogger logs the name, outcome and duration of every following executed step
+// using DefaultLogLevels. Steps skipped because the chain is off track are not logged.
+func WithLogger[T any](c *Chain[T], logger *slog.Logger) *Chain[T] {
+	return WithLoggerLevels(c, logger, DefaultLogLevels)
+}
+
+// WithLoggerLevels is WithLogger with explicit levels per outcome
+func WithLoggerLevels[T any](c *Chain[T], logger *slog.Logger, levels LogLevels) *Chain[T] {
+	return Observe(c, func(ctx context.Context, e StepEvent) {
+		if e.Skipped {
+			return
+		}
+
+		level := levels.Success
+		switch e.Output {
+		case OutcomeFailure:
+			level = levels.Failure
+		case OutcomeCancel:
+			level = levels.Cancel
+		}
+
+		attrs := []slog.Attr{
+			slog.String("step", e.Name),
+			slog.String("outcome", string(e.Output)),
+			slog.Duration("duration", e.Duration),
+		}
+		if e.Err != nil {
+			attrs = append(attrs, slog.Any("error", e.Err))
+		}
+		logger.LogAttrs(ctx, level, "chain step", attrs...)
+	})
+}
@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// RepeatUntil runs the nested chain built by inC at least once and keeps
+// repeating it while until holds for the new value (same semantics as tiny).
+// The loop stops when the chain goes off track or the chain context is done.
+func (c *Chain[T]) RepeatUntil(inC func(ctx context.Context, t T) *Chain[T],
+	until func(ctx context.Context, t T) bool) *Chain[T] {
+	return RepeatUntilChain(c, func(c *Chain[T]) *Chain[T] {
+		return inC(c.ctx, c.result.Result())
+	}, until)
+}
+
+// While repeats the nested chain built by inC as long as while holds for the current value
+func (c *Chain[T]) While(inC func(ctx context.Context, t T) *Chain[T],
+	while func(ctx context.Context, t T) bool) *Chain[T] {
+	return WhileChain(c, func(c *Chain[T]) *Chain[T] {
+		return inC(c.ctx, c.result.Result())
+	}, while)
+}
+
+// RepeatUntilChain is RepeatUntil whose body continues the current chain, so
+// it may pass through other types with the package-level functions (Then,
+// Map, ThenTry, ...) as long as it comes back to T.
+func RepeatUntilChain[T any](c *Chain[T], body func(*Chain[T]) *Chain[T],
+	until func(ctx context.Context, t T) bool) *Chain[T] {
+
+	if !onTrack(c) {
+		return c
+	}
+
+	for {
+		if c.ctx.Err() != nil {
+			return derive(c, rop.Cancel[T](context.Cause(c.ctx)))
+		}
+
+		c = body(c)
+
+		if !onTrack(c) || !until(c.ctx, c.result.Result()) {
+			return c
+		}
+	}
+}
+
+// WhileChain is While whose body continues the current chain (see RepeatUntilChain)
+func WhileChain[T any](c *Chain[T], body func(*Chain[T]) *Chain[T],
+	while func(ctx context.Context, t T) bool) *Chain[T] {
+
+	for onTrack(c) && while(c.ctx, c.result.Result()) {
+		if c.ctx.Err() != nil {
+			return derive(c, rop.Cancel[T](context.Cause(c.ctx)))
+		}
+		c = body(c)
+	}
+	return c
+}
+
+func onTrack[T any](c *Chain[T]) bool {
+	return c.result.IsSuccess() && !c.result.IsProcessed()
+}
@@ -0,0 +1,145 @@
+package chain
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	OutcomeCancel  Outcome = "cancel"
+)
+
+// StepEvent describes one executed (or skipped) chain step
+type StepEvent struct {
+	Name     string
+	Input    Outcome
+	Output   Outcome
+	Err      error
+	Duration time.Duration
+	// Skipped is true when the step function was not called because the chain was off track
+	Skipped bool
+	// Attempt numbers the re-runs of a step by Retry/RetryIf, 0 for its first run
+	Attempt int
+}
+
+// Observe registers observer to be called after every following step of the chain
+func Observe[T any](c *Chain[T], observer func(context.Context, StepEvent)) *Chain[T] {
+	observers := make([]func(context.Context, StepEvent), 0, len(c.observers)+1)
+	observers = append(observers, c.observers...)
+	observers = append(observers, observer)
+
+	return &Chain[T]{
+		ctx:       c.ctx,
+		result:    c.result,
+		retry:     c.retry,
+		observers: observers,
+		name:      c.name,
+	}
+}
+
+// Named names the next step of the chain for its observers, in place of the
+// name of its function (closures are only known as e.g. "pkg.Func.func1").
+func (c *Chain[T]) Named(name string) *Chain[T] {
+	named := *c
+	named.name = name
+	return &named
+}
+
+func outcomeOf[T any](r rop.Result[T]) Outcome {
+	switch {
+	case r.IsSuccess():
+		return OutcomeSuccess
+	case r.IsCancel():
+		return OutcomeCancel
+	default:
+		return OutcomeFailure
+	}
+}
+
+// derive continues c with a new result, keeping the chain context and observers
+func derive[T, U any](c *Chain[T], result rop.Result[U]) *Chain[U] {
+	return &Chain[U]{
+		ctx:       c.ctx,
+		result:    result,
+		observers: c.observers,
+	}
+}
+
+// step executes a chain step and reports it to the chain observers.
+// fn is the user function, used to name the step; the step counts as skipped
+// when the chain is off track.
+func step[T, U any](c *Chain[T], fn any, exec func() rop.Result[U]) *Chain[U] {
+	return derive(c, observed(c, fn, !c.result.IsSuccess(), exec))
+}
+
+// observed runs exec, reporting it to the chain observers as a step named
+// after fn (or the name set with Named) that ran on c.result.
+func observed[T, U any](c *Chain[T], fn any, skipped bool, exec func() rop.Result[U]) rop.Result[U] {
+	return observe(c.ctx, c.observers, StepEvent{
+		Name:    c.stepName(fn),
+		Input:   outcomeOf(c.result),
+		Skipped: skipped,
+	}, exec)
+}
+
+// observe runs exec and notifies observers of event completed with its result.
+func observe[U any](ctx context.Context, observers []func(context.Context, StepEvent),
+	event StepEvent, exec func() rop.Result[U]) rop.Result[U] {
+	if len(observers) == 0 {
+		return exec()
+	}
+
+	start := time.Now()
+	result := exec()
+	event.Output = outcomeOf(result)
+	event.Err = result.Err()
+	event.Duration = time.Since(start)
+	notify(ctx, observers, event)
+	return result
+}
+
+func notify(ctx context.Context, observers []func(context.Context, StepEvent), event StepEvent) {
+	for _, observer := range observers {
+		observer(ctx, event)
+	}
+}
+
+// stepName is the name set with Named, else that of fn (which may also be
+// the name itself).
+func (c *Chain[T]) stepName(fn any) string {
+	if c.name != "" {
+		return c.name
+	}
+	return stepName(fn)
+}
+
+func stepName(fn any) string {
+	if name, ok := fn.(string); ok {
+		return name
+	}
+
+	v := reflect.ValueOf(fn)
+	if !v.IsValid() || v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+
+	name := f.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
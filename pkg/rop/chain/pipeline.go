@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/solo"
+)
+
+// Pipeline is a chain of steps defined once and executed against many inputs.
+// Its step function can be run synchronously (Run, RunValue) or lifted into a
+// channel engine with lite.Step.
+type Pipeline[T, U any] struct {
+	step func(ctx context.Context, input rop.Result[T]) rop.Result[U]
+}
+
+// Define starts a pipeline definition whose input and output type is T
+func Define[T any]() Pipeline[T, T] {
+	return Pipeline[T, T]{
+		step: func(_ context.Context, input rop.Result[T]) rop.Result[T] { return input },
+	}
+}
+
+// PipeThen appends a step returning rop.Result[V]
+func PipeThen[T, U, V any](p Pipeline[T, U],
+	onSuccess func(context.Context, U) rop.Result[V]) Pipeline[T, V] {
+	return Pipeline[T, V]{step: solo.Compose2(p.step, solo.SwitchStep(onSuccess))}
+}
+
+// PipeTry appends a step returning (V, error)
+func PipeTry[T, U, V any](p Pipeline[T, U],
+	tryOnSuccess func(context.Context, U) (V, error)) Pipeline[T, V] {
+	return Pipeline[T, V]{step: solo.Compose2(p.step, solo.TryStep(tryOnSuccess))}
+}
+
+// PipeMap appends a pure transformation
+func PipeMap[T, U, V any](p Pipeline[T, U],
+	onSuccess func(context.Context, U) V) Pipeline[T, V] {
+	return Pipeline[T, V]{step: solo.Compose2(p.step, solo.MapStep(onSuccess))}
+}
+
+// Step returns the fused step function, e.g. for lite.Step or custom.Step
+func (p Pipeline[T, U]) Step() func(ctx context.Context, input rop.Result[T]) rop.Result[U] {
+	return p.step
+}
+
+// Run executes the pipeline against a result
+func (p Pipeline[T, U]) Run(ctx context.Context, input rop.Result[T]) rop.Result[U] {
+	return p.step(ctx, input)
+}
+
+// RunValue executes the pipeline against a successful value
+func (p Pipeline[T, U]) RunValue(ctx context.Context, value T) rop.Result[U] {
+	return p.step(ctx, rop.Success(value))
+}
+
+// Apply continues a chain with the pipeline steps
+func Apply[T, U any](c *Chain[T], p Pipeline[T, U]) *Chain[U] {
+	return step(c, p.step, func() rop.Result[U] {
+		return p.step(c.ctx, c.result)
+	})
+}
@@ -0,0 +1,57 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Retry re-runs the preceding ThenTry step up to n more times while it fails,
+// waiting backoff(attempt) between attempts. Cancels are never retried.
+// Observers see every attempt as a step of its own (see StepEvent.Attempt).
+func (c *Chain[T]) Retry(n int, backoff core.Backoff) *Chain[T] {
+	return c.RetryIf(n, backoff, func(error) bool { return true })
+}
+
+// retried is a ThenTry step that Retry can run again
+type retried[T any] struct {
+	name  string
+	input Outcome
+	run   func() rop.Result[T]
+}
+
+// RetryIf is Retry limited to failures whose error satisfies retryable
+func (c *Chain[T]) RetryIf(n int, backoff core.Backoff, retryable func(err error) bool) *Chain[T] {
+	if c.retry == nil {
+		return c
+	}
+
+	result := c.result
+	for attempt := 1; attempt <= n; attempt++ {
+		if result.IsSuccess() || result.IsCancel() || !retryable(result.Err()) {
+			break
+		}
+
+		if backoff != nil {
+			if err := core.Sleep(c.ctx, backoff(attempt)); err != nil {
+				result = rop.Cancel[T](err)
+				break
+			}
+		} else if c.ctx.Err() != nil {
+			result = rop.Cancel[T](context.Cause(c.ctx))
+			break
+		}
+
+		result = observe(c.ctx, c.observers, StepEvent{
+			Name:    c.retry.name,
+			Input:   c.retry.input,
+			Skipped: c.retry.input != OutcomeSuccess,
+			Attempt: attempt,
+		}, c.retry.run)
+	}
+
+	next := derive(c, result)
+	next.retry = c.retry
+	return next
+}
@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// SafeThen is Then that converts a panic in onSuccess into a failure carrying a *rop.PanicError
+func SafeThen[T, U any](c *Chain[T],
+	onSuccess func(context.Context, T) rop.Result[U]) *Chain[U] {
+	return safeThen(c, onSuccess, onSuccess)
+}
+
+// safeThen is SafeThen naming the step after fn
+func safeThen[T, U any](c *Chain[T], fn any,
+	onSuccess func(context.Context, T) rop.Result[U]) *Chain[U] {
+	return then(c, fn, func(ctx context.Context, v T) (res rop.Result[U]) {
+		defer func() {
+			if r := recover(); r != nil {
+				res = rop.Fail[U](rop.NewPanicError(r))
+			}
+		}()
+		return onSuccess(ctx, v)
+	})
+}
+
+// SafeThenTry is ThenTry that converts a panic in tryOnSuccess into a failure
+func SafeThenTry[T, U any](c *Chain[T],
+	tryOnSuccess func(context.Context, T) (U, error)) *Chain[U] {
+	return thenTry(c, tryOnSuccess, func(ctx context.Context, v T) (out U, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = rop.NewPanicError(r)
+			}
+		}()
+		return tryOnSuccess(ctx, v)
+	})
+}
+
+// SafeMap is Map that converts a panic in onSuccess into a failure
+func SafeMap[T, U any](c *Chain[T],
+	onSuccess func(context.Context, T) U) *Chain[U] {
+	return safeThen(c, onSuccess, func(ctx context.Context, v T) rop.Result[U] {
+		return rop.Success(onSuccess(ctx, v))
+	})
+}
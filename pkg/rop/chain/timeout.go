@@ -0,0 +1,43 @@
+package chain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// ThenTryWithTimeout is ThenTry with the step running under a deadline of d.
+// When the deadline passes before the step returns the chain is canceled.
+func ThenTryWithTimeout[T, U any](c *Chain[T], d time.Duration,
+	tryOnSuccess func(context.Context, T) (U, error)) *Chain[U] {
+	return thenTry(c, tryOnSuccess, withTimeout(d, tryOnSuccess))
+}
+
+func withTimeout[T, U any](d time.Duration,
+	try func(context.Context, T) (U, error)) func(context.Context, T) (U, error) {
+
+	type outcome struct {
+		value U
+		err   error
+	}
+
+	return func(ctx context.Context, in T) (U, error) {
+		stepCtx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+
+		done := make(chan outcome, 1)
+		core.Go(ctx, func() {
+			v, err := try(stepCtx, in)
+			done <- outcome{value: v, err: err}
+		})
+
+		select {
+		case o := <-done:
+			return o.value, o.err
+		case <-stepCtx.Done():
+			var zero U
+			return zero, stepCtx.Err()
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package chain
+
+import (
+	"context"
+	"sync"
+)
+
+// StepTrace is a recorded chain step, Index being its position in the chain
+type StepTrace struct {
+	Index int
+	StepEvent
+}
+
+// TraceRecorder collects the steps of a traced chain
+type TraceRecorder struct {
+	mu    sync.Mutex
+	steps []StepTrace
+}
+
+// Trace records every following step (including skipped ones) of the chain.
+// The recorder stays readable after the chain has been collapsed with Finally.
+func Trace[T any](c *Chain[T]) (*Chain[T], *TraceRecorder) {
+	recorder := &TraceRecorder{}
+	return Observe(c, recorder.record), recorder
+}
+
+func (r *TraceRecorder) record(_ context.Context, e StepEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.steps = append(r.steps, StepTrace{Index: len(r.steps), StepEvent: e})
+}
+
+// Steps returns a copy of the recorded steps in execution order
+func (r *TraceRecorder) Steps() []StepTrace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	steps := make([]StepTrace, len(r.steps))
+	copy(steps, r.steps)
+	return steps
+}
@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Zip joins two independent chains with combine. When either chain is off
+// track, a cancel takes precedence over a failure and errors of both sides are
+// joined (first chain first). The result continues with the first chain context.
+func Zip[A, B, C any](c1 *Chain[A], c2 *Chain[B],
+	combine func(context.Context, A, B) rop.Result[C]) *Chain[C] {
+
+	r1, r2 := c1.result, c2.result
+	if r1.IsSuccess() && r2.IsSuccess() {
+		return step(c1, combine, func() rop.Result[C] {
+			return combine(c1.ctx, r1.Result(), r2.Result())
+		})
+	}
+
+	errs := make([]error, 0, 2)
+	canceled := false
+	if !r1.IsSuccess() {
+		errs = append(errs, r1.Err())
+		canceled = r1.IsCancel()
+	}
+	if !r2.IsSuccess() {
+		errs = append(errs, r2.Err())
+		canceled = canceled || r2.IsCancel()
+	}
+
+	err := errs[0]
+	if len(errs) > 1 {
+		err = errors.Join(errs...)
+	}
+	return step(c1, combine, func() rop.Result[C] {
+		if canceled {
+			return rop.Cancel[C](err)
+		}
+		return rop.Fail[C](err)
+	})
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// DedupeStore remembers keys that have already been emitted.
+type DedupeStore[K comparable] interface {
+	// MarkSeen records key and reports whether it had been recorded before
+	MarkSeen(ctx context.Context, key K) (seen bool)
+}
+
+type MemoryDedupeStore[K comparable] struct {
+	mu   sync.Mutex
+	keys map[K]struct{}
+}
+
+func NewMemoryDedupeStore[K comparable]() *MemoryDedupeStore[K] {
+	return &MemoryDedupeStore[K]{keys: make(map[K]struct{})}
+}
+
+func (s *MemoryDedupeStore[K]) MarkSeen(_ context.Context, key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key]; ok {
+		return true
+	}
+	s.keys[key] = struct{}{}
+	return false
+}
+
+// BackfillThenTail drains the historical backfill source first and then
+// switches to the live source. Successful backfill items are recorded in
+// store, and the live source is checked against it only for the overlap: up
+// to its first key the backfill did not have. From then on live items pass
+// through unchecked, so store stops growing and duplicates inside the live
+// stream itself are kept. Failures and cancels are always forwarded.
+func BackfillThenTail[T any, K comparable](ctx context.Context,
+	backfill <-chan rop.Result[T], live <-chan rop.Result[T],
+	keyFn func(v T) K, store DedupeStore[K]) <-chan rop.Result[T] {
+
+	if store == nil {
+		store = NewMemoryDedupeStore[K]()
+	}
+	out := make(chan rop.Result[T])
+
+	Go(ctx, func() {
+		defer close(out)
+
+		if !forwardUnique(ctx, backfill, out, keyFn, store, false) {
+			return
+		}
+		forwardUnique(ctx, live, out, keyFn, store, true)
+	})
+
+	return out
+}
+
+// forwardUnique forwards source minus the successful items store has seen.
+// With overlapOnly it stops checking at the first unseen key.
+func forwardUnique[T any, K comparable](ctx context.Context, source <-chan rop.Result[T],
+	out chan<- rop.Result[T], keyFn func(v T) K, store DedupeStore[K], overlapOnly bool) bool {
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case r, ok := <-source:
+			if !ok {
+				return true
+			}
+
+			if r.IsSuccess() && store != nil {
+				if store.MarkSeen(ctx, keyFn(r.Result())) {
+					continue
+				}
+				if overlapOnly {
+					// past the overlap: let go of the store
+					store = nil
+				}
+			}
+
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return false
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryError is the final failure of an item that was retried.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Backoff returns the delay to wait before the given retry attempt (starting at 1).
+type Backoff func(attempt int) time.Duration
+
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff doubles base on every attempt, capped at max (when max > 0).
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt; i++ {
+			d *= 2
+			if max > 0 && d >= max {
+				return max
+			}
+		}
+		if max > 0 && d > max {
+			return max
+		}
+		return d
+	}
+}
+
+// Sleep waits for d or until ctx is done, returning the context cause in the latter case.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
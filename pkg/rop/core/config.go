@@ -0,0 +1,90 @@
+package core
+
+import "context"
+
+// Options configures a Run, Turnout or Finally call. The runners read it
+// directly; ResolveOptions fills it from the context-value options first,
+// which remain for backward compatibility.
+type Options struct {
+	// Workers overrides the lines argument when > 0
+	Workers int
+	// ProcessRemaining, when set, is what the cancel handlers of the stage see
+	ProcessRemaining *bool
+	Buffer           BufferOptions
+	Name             string
+}
+
+type Option func(o *Options)
+
+// WithWorkers overrides the number of lines a Run or Turnout starts.
+func WithWorkers(n int) Option {
+	return func(o *Options) {
+		o.Workers = n
+	}
+}
+
+func WithProcessRemaining(processRemaining bool) Option {
+	return func(o *Options) {
+		o.ProcessRemaining = &processRemaining
+	}
+}
+
+// WithBuffer sizes the stage output; policy defaults to OverflowBlock.
+func WithBuffer(size int, policy ...OverflowPolicy) Option {
+	return func(o *Options) {
+		o.Buffer = BufferOptions{Size: size}
+		if len(policy) > 0 {
+			o.Buffer.Policy = policy[0]
+		}
+	}
+}
+
+// WithName names the stage, as WithStageName does.
+func WithName(name string) Option {
+	return func(o *Options) {
+		o.Name = name
+	}
+}
+
+// ResolveOptions is the Options of a call: those set on ctx, overridden by opts.
+func ResolveOptions(ctx context.Context, opts ...Option) Options {
+	o := Options{Buffer: GetBufferOptions(ctx)}
+	if options, ok := ctx.Value(ProcessOptionKey).(ProcessOptions); ok {
+		o.ProcessRemaining = &options.ProcessRemaining
+	}
+	o.Name, _ = GetStageName(ctx)
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// Context returns ctx carrying the settings the stage's handlers and engines
+// read from their ctx: ProcessRemaining and Name.
+func (o Options) Context(ctx context.Context) context.Context {
+	if o.ProcessRemaining != nil {
+		ctx = WithProcessOptions(ctx, *o.ProcessRemaining)
+	}
+	if o.Name != "" {
+		ctx = WithStageName(ctx, o.Name)
+	}
+	return ctx
+}
+
+// Lines returns the number of lines to start: Workers when set, else lines.
+func (o Options) Lines(lines int) int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return lines
+}
+
+// ApplyOptions resolves the options of a call and returns ctx prepared with
+// Options.Context along with them.
+func ApplyOptions(ctx context.Context, opts ...Option) (context.Context, Options) {
+	o := ResolveOptions(ctx, opts...)
+	return o.Context(ctx), o
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type countingStore struct {
+	*MemoryDedupeStore[int]
+	marks int
+}
+
+func (s *countingStore) MarkSeen(ctx context.Context, key int) bool {
+	s.marks++
+	return s.MemoryDedupeStore.MarkSeen(ctx, key)
+}
+
+func TestBackfillThenTail_DedupesOverlapOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	store := &countingStore{MemoryDedupeStore: NewMemoryDedupeStore[int]()}
+	out := BackfillThenTail(ctx, ToChanManyResults(ctx, []int{1, 2, 3}), ToChanManyResults(ctx, []int{2, 3, 4, 4, 5}),
+		func(v int) int { return v }, store)
+
+	var got []int
+	for _, r := range FromChanMany(ctx, out) {
+		got = append(got, r.Result())
+	}
+
+	if !slices.Equal(got, []int{1, 2, 3, 4, 4, 5}) {
+		t.Fatalf("expected the overlap dropped and live duplicates kept, got %v", got)
+	}
+	// 3 backfill keys and the live ones up to the first new key
+	if store.marks != 6 {
+		t.Fatalf("expected the store left alone past the overlap, got %d marks", store.marks)
+	}
+}
+
+func TestBackfillThenTail_ForwardsFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	backfill := make(chan rop.Result[int], 2)
+	backfill <- rop.Success(1)
+	backfill <- rop.Fail[int](context.Canceled)
+	close(backfill)
+
+	got := FromChanMany(ctx, BackfillThenTail(ctx, backfill, ToChanManyResults(ctx, []int{1, 2}),
+		func(v int) int { return v }, nil))
+	if len(got) != 3 || got[1].IsSuccess() || got[2].Result() != 2 {
+		t.Fatalf("expected 1, the failure and 2, got %v", got)
+	}
+}
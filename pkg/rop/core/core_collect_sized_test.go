@@ -0,0 +1,46 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestFromChanManySized_Closed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	values, err := FromChanManySized(ctx, ToChanMany(ctx, []int{1, 2, 3}), 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(values) != 3 || cap(values) != 3 {
+		t.Fatalf("expected 3 values in a preallocated slice, got %v (cap %d)", values, cap(values))
+	}
+}
+
+func TestFromChanManySized_CancelCause(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	errShutdown := errors.New("shutdown")
+
+	out := make(chan rop.Result[int])
+	go func() {
+		out <- rop.Success(1)
+		cancel(errShutdown)
+	}()
+
+	values, err := FromChanManySized(ctx, out, 0)
+	if !errors.Is(err, errShutdown) {
+		t.Fatalf("expected the cancel cause, got %v", err)
+	}
+	if len(values) != 1 {
+		t.Fatalf("expected the value collected before the cancel, got %v", values)
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGroup_WaitTimeoutSharesWaiter(t *testing.T) {
+	t.Parallel()
+
+	ctx, g := WithGroup(context.Background())
+	release := make(chan struct{})
+	Go(ctx, func() { <-release })
+
+	if err := g.WaitTimeout(time.Millisecond); err == nil {
+		t.Fatalf("expected a timeout while a goroutine is alive")
+	}
+	idle := g.waitIdle()
+	if err := g.WaitTimeout(time.Millisecond); err == nil {
+		t.Fatalf("expected a timeout while a goroutine is alive")
+	}
+	if g.waitIdle() != idle {
+		t.Fatalf("expected timed out waits to share one waiter")
+	}
+
+	close(release)
+	if err := g.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if g.idle != nil {
+		t.Fatalf("expected the waiter to end once the group is idle")
+	}
+}
+
+func TestGroup_NestedGroupsShareGoroutines(t *testing.T) {
+	t.Parallel()
+
+	ctx, outer := WithGroup(context.Background())
+	ctx, inner := WithGroup(ctx)
+
+	release := make(chan struct{})
+	Go(ctx, func() { <-release })
+
+	if outer.Active() != 1 || inner.Active() != 1 {
+		t.Fatalf("expected both groups to own the goroutine, got %d and %d", outer.Active(), inner.Active())
+	}
+
+	close(release)
+	outer.Wait()
+	if inner.Active() != 0 {
+		t.Fatalf("expected the inner group idle once the outer one is")
+	}
+}
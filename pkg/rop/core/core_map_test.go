@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestMap_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	prices := map[string]int{"apple": 3, "pear": 4, "plum": 0}
+	errNoPrice := errors.New("no price")
+
+	engine := func(kv KV[string, int]) rop.Result[KV[string, int]] {
+		if kv.Value == 0 {
+			return rop.Fail[KV[string, int]](errNoPrice)
+		}
+		return rop.Success(KV[string, int]{Key: strings.ToUpper(kv.Key), Value: kv.Value * 2})
+	}
+
+	got, err := FromChanToMap(ctx, mapped(ToChanFromMap(ctx, prices), engine))
+
+	expected := map[string]int{"APPLE": 6, "PEAR": 8}
+	if !maps.Equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if !errors.Is(err, errNoPrice) {
+		t.Fatalf("expected errNoPrice, got %v", err)
+	}
+}
+
+func TestFromChanToMap_LaterEntryWins(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got, err := FromChanToMap(ctx, ToChanManyResults(ctx, []KV[int, string]{
+		{Key: 1, Value: "a"}, {Key: 2, Value: "b"}, {Key: 1, Value: "c"},
+	}))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !maps.Equal(got, map[int]string{1: "c", 2: "b"}) {
+		t.Fatalf("expected the later entry kept, got %v", got)
+	}
+}
+
+func TestFromChanToMap_CancelCause(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	errShutdown := errors.New("shutdown")
+
+	out := make(chan rop.Result[KV[string, int]])
+	go func() {
+		out <- rop.Success(KV[string, int]{Key: "a", Value: 1})
+		cancel(errShutdown)
+	}()
+
+	got, err := FromChanToMap(ctx, out)
+	if !errors.Is(err, errShutdown) {
+		t.Fatalf("expected the cancel cause, got %v", err)
+	}
+	if !maps.Equal(got, map[string]int{"a": 1}) {
+		t.Fatalf("expected the entry collected before the cancel, got %v", got)
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestFromReaderLines(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := FromReaderLines(ctx, strings.NewReader("a\r\nbb\n\nccc"), ReaderLinesOptions{})
+	results := FromChanMany(ctx, mapped(in, func(s string) rop.Result[int] {
+		return rop.Success(len(s))
+	}))
+
+	var got []int
+	for _, r := range results {
+		if !r.IsSuccess() {
+			t.Fatalf("expected only successes, got %v", r.Err())
+		}
+		got = append(got, r.Result())
+	}
+	if !slices.Equal(got, []int{1, 2, 0, 3}) {
+		t.Fatalf("expected [1 2 0 3], got %v", got)
+	}
+}
+
+func TestFromReaderLines_TooLong(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := FromChanMany(ctx, FromReaderLines(ctx,
+		strings.NewReader("short\n"+strings.Repeat("x", 100)+"\nafter\n"),
+		ReaderLinesOptions{MaxLineLength: 16}))
+
+	if len(results) != 2 {
+		t.Fatalf("expected a line and a failure, got %d results", len(results))
+	}
+	if !results[0].IsSuccess() || results[0].Result() != "short" {
+		t.Fatalf("expected the short line first, got %v", results[0])
+	}
+	if !errors.Is(results[1].Err(), bufio.ErrTooLong) {
+		t.Fatalf("expected bufio.ErrTooLong, got %v", results[1].Err())
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk gone")
+}
+
+func TestFromReaderLines_ReadError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := FromChanMany(ctx, FromReaderLines(ctx, failingReader{}, ReaderLinesOptions{}))
+	if len(results) != 1 || results[0].IsSuccess() || results[0].Err().Error() != "disk gone" {
+		t.Fatalf("expected the read error as a failure, got %v", results)
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// mapped is a single line stage applying f to the successes of in.
+func mapped[In, Out any](in <-chan rop.Result[In], f func(In) rop.Result[Out]) <-chan rop.Result[Out] {
+	out := make(chan rop.Result[Out])
+	go func() {
+		defer close(out)
+		for r := range in {
+			switch {
+			case r.IsSuccess():
+				out <- f(r.Result())
+			case r.IsCancel():
+				out <- rop.Cancel[Out](r.Err())
+			default:
+				out <- rop.Fail[Out](r.Err())
+			}
+		}
+	}()
+	return out
+}
+
+func TestSeq_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	errBadValue := errors.New("bad value")
+	engine := func(v int) rop.Result[int] {
+		if v == 3 {
+			return rop.Fail[int](errBadValue)
+		}
+		return rop.Success(v * 10)
+	}
+
+	var got []int
+	var errs []error
+	for v, err := range ToSeq(ctx, mapped(FromSeq(ctx, slices.Values([]int{1, 2, 3, 4})), engine)) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got = append(got, v)
+	}
+
+	if !slices.Equal(got, []int{10, 20, 40}) {
+		t.Fatalf("expected [10 20 40], got %v", got)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errBadValue) {
+		t.Fatalf("expected one errBadValue, got %v", errs)
+	}
+}
+
+func TestFromSeq_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stopped := make(chan struct{})
+	endless := func(yield func(int) bool) {
+		defer close(stopped)
+		for i := 0; yield(i); i++ {
+		}
+	}
+
+	out := FromSeq(ctx, endless)
+	<-out
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the sequence stopped after cancel")
+	}
+	for range out {
+	}
+}
+
+func TestToSeq_YieldsCause(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	results := make(chan rop.Result[string])
+	stop := errors.New("stop")
+	cancel(stop)
+
+	var errs []error
+	for _, err := range ToSeq(ctx, results) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], stop) {
+		t.Fatalf("expected the cancel cause, got %v", errs)
+	}
+}
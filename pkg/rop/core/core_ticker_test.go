@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFromTicker(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errDown := errors.New("down")
+	polls := 0
+	in := FromTicker(ctx, 5*time.Millisecond, func(ctx context.Context, tick time.Time) (int, error) {
+		polls++
+		if polls%2 == 0 {
+			return 0, errDown
+		}
+		return polls, nil
+	})
+
+	var succeeded, failed int
+	for r := range in {
+		if r.IsSuccess() {
+			succeeded++
+		} else if errors.Is(r.Err(), errDown) {
+			failed++
+		}
+		if succeeded+failed == 4 {
+			cancel()
+		}
+	}
+
+	if succeeded < 2 || failed < 2 {
+		t.Fatalf("expected alternating results, got %d succeeded and %d failed", succeeded, failed)
+	}
+}
+
+func TestFromTicker_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out := FromTicker(ctx, time.Millisecond, func(ctx context.Context, tick time.Time) (int, error) {
+		return 1, nil
+	})
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatalf("expected no results after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the ticker source closed")
+	}
+}
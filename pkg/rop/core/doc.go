@@ -2,4 +2,11 @@
 // configuration via context, and the locomotive that drives stages. It does
 // not define business logic; instead it provides the scaffolding for packages
 // like lite, mass, and custom to run pipelines with controlled concurrency.
-package core
\ No newline at end of file
+//
+// Sources and sinks:
+// - FromReaderLines: stream the lines of a file into a pipeline instead of loading it into a slice
+// - FromTicker: poll on an interval (health checks, scrapers) as a stream of results
+// - FromSeq/ToSeq: feed a pipeline from an iter.Seq and range over its results with their errors
+// - ToChanFromMap/FromChanToMap: run map entries (KV) through a pipeline and collect them back
+// - FromChanManySized: collect with a size hint, reporting a ctx cancel (with its cause) as an error
+package core
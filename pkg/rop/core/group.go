@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const GroupKey OptionKey = "goroutine_group"
+
+// Group owns every goroutine spawned through Go for a context carrying it,
+// so a pipeline can wait for (or assert) the end of all its background work.
+type Group struct {
+	wg     sync.WaitGroup
+	active atomic.Int64
+	total  atomic.Int64
+	// parent also owns the goroutines of the group
+	parent *Group
+
+	mu sync.Mutex
+	// idle is closed by the waiter of WaitTimeout once the group is idle
+	idle chan struct{}
+}
+
+// WithGroup returns ctx carrying a new Group. A Group already carried by ctx
+// stays the owner of the goroutines of the new one as well.
+func WithGroup(ctx context.Context) (context.Context, *Group) {
+	g := &Group{}
+	g.parent, _ = GetGroup(ctx)
+	return context.WithValue(ctx, GroupKey, g), g
+}
+
+func GetGroup(ctx context.Context) (*Group, bool) {
+	g, ok := ctx.Value(GroupKey).(*Group)
+	return g, ok
+}
+
+// Go starts f in a new goroutine, accounting for it in the Group carried by ctx (if any).
+func Go(ctx context.Context, f func()) {
+	if onPanic, ok := GetPanicHandler(ctx); ok {
+		f = recovering(f, onPanic)
+	}
+
+	g, ok := GetGroup(ctx)
+	if !ok {
+		go f()
+		return
+	}
+
+	for owner := g; owner != nil; owner = owner.parent {
+		owner.wg.Add(1)
+		owner.active.Add(1)
+		owner.total.Add(1)
+	}
+	go func() {
+		defer func() {
+			for owner := g; owner != nil; owner = owner.parent {
+				owner.active.Add(-1)
+				owner.wg.Done()
+			}
+		}()
+		f()
+	}()
+}
+
+// Wait blocks until every goroutine owned by the group has returned.
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
+
+// WaitTimeout is Wait bounded by d; it returns an error naming the number of
+// goroutines still alive when d elapses. Calls share a single waiter
+// goroutine, which ends once the group is idle.
+func (g *Group) WaitTimeout(d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-g.waitIdle():
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%d goroutine(s) outlived the pipeline", g.active.Load())
+	}
+}
+
+// waitIdle returns a channel closed once the group is idle, starting the
+// waiter unless one is already running.
+func (g *Group) waitIdle() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.idle == nil {
+		idle := make(chan struct{})
+		g.idle = idle
+		go func() {
+			g.wg.Wait()
+			g.mu.Lock()
+			g.idle = nil
+			g.mu.Unlock()
+			close(idle)
+		}()
+	}
+	return g.idle
+}
+
+// AssertIdle is a debug check that no owned goroutine is still running.
+func (g *Group) AssertIdle() error {
+	if n := g.active.Load(); n != 0 {
+		return fmt.Errorf("%d goroutine(s) outlived the pipeline", n)
+	}
+	return nil
+}
+
+func (g *Group) Active() int64 {
+	return g.active.Load()
+}
+
+func (g *Group) Total() int64 {
+	return g.total.Load()
+}
@@ -17,7 +17,7 @@ type ToChanHandlers[T any] struct {
 func ToChanFromArgs[T any](ctx context.Context, values ...T) <-chan T {
 	in := make(chan T)
 
-	go func() {
+	Go(ctx, func() {
 		defer close(in)
 
 		if ctx.Err() != nil {
@@ -40,7 +40,7 @@ func ToChanFromArgs[T any](ctx context.Context, values ...T) <-chan T {
 				return
 			}
 		}
-	}()
+	})
 
 	return in
 }
@@ -48,7 +48,7 @@ func ToChanFromArgs[T any](ctx context.Context, values ...T) <-chan T {
 func ToChanFromArgsResults[T any](ctx context.Context, handlers ToChanHandlers[T], values ...T) <-chan rop.Result[T] {
 	in := make(chan rop.Result[T])
 
-	go func() {
+	Go(ctx, func() {
 		defer close(in)
 
 		if ctx.Err() != nil {
@@ -76,7 +76,7 @@ func ToChanFromArgsResults[T any](ctx context.Context, handlers ToChanHandlers[T
 				return
 			}
 		}
-	}()
+	})
 
 	return in
 }
@@ -89,7 +89,7 @@ func FromChanFirstOrDefault[T any](ctx context.Context, out <-chan T, defaultV T
 	res := defaultV
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
-	go func() {
+	Go(ctx, func() {
 		defer wg.Done()
 
 		select {
@@ -102,7 +102,7 @@ func FromChanFirstOrDefault[T any](ctx context.Context, out <-chan T, defaultV T
 		case <-ctx.Done():
 			return
 		}
-	}()
+	})
 	wg.Wait()
 	return res
 }
@@ -120,11 +120,20 @@ func ToChanManyResults[T any](ctx context.Context, values []T) <-chan rop.Result
 }
 
 func FromChanMany[T any](ctx context.Context, out <-chan T) []T {
-	res := make([]T, 0)
+	res, _ := FromChanManySized(ctx, out, 0)
+	return res
+}
+
+// FromChanManySized is FromChanMany preallocating for sizeHint values. It
+// returns the cause of ctx when ctx ended the collection before out was
+// closed, along with the values collected until then, and nil otherwise.
+func FromChanManySized[T any](ctx context.Context, out <-chan T, sizeHint int) ([]T, error) {
+	res := make([]T, 0, max(sizeHint, 0))
+	var err error
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 
-	go func() {
+	Go(ctx, func() {
 		defer wg.Done()
 		for {
 			select {
@@ -134,11 +143,74 @@ func FromChanMany[T any](ctx context.Context, out <-chan T) []T {
 				}
 				res = append(res, v)
 			case <-ctx.Done():
+				err = context.Cause(ctx)
 				return
 			}
 		}
-	}()
+	})
 
 	wg.Wait()
-	return res
+	return res, err
+}
+
+func LiftChan[T any](ctx context.Context, values <-chan T) <-chan rop.Result[T] {
+	out := make(chan rop.Result[T])
+
+	Go(ctx, func() {
+		defer close(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-values:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- rop.Success(v):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out
+}
+
+func LowerChan[T any](ctx context.Context, results <-chan rop.Result[T],
+	onError func(ctx context.Context, r rop.Result[T])) <-chan T {
+	out := make(chan T)
+
+	Go(ctx, func() {
+		defer close(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-results:
+				if !ok {
+					return
+				}
+
+				if !r.IsSuccess() {
+					if onError != nil {
+						onError(ctx, r)
+					}
+					continue
+				}
+
+				select {
+				case out <- r.Result():
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out
 }
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// locomotiveHooksKey keys the hooks of one stage by its name and the types it
+// carries.
+type locomotiveHooksKey[In, Out any] struct {
+	stage string
+}
+
+// LocomotiveHooks observe every item a Locomotive (or Dispatch) carries, for
+// metrics, tracing or logging without touching the engine. Nil hooks are
+// skipped.
+type LocomotiveHooks[In, Out any] struct {
+	// OnReceive is called when the line takes in from the input
+	OnReceive func(ctx context.Context, in rop.Result[In])
+	// OnEngineStart is called right before the engine is started for in
+	OnEngineStart func(ctx context.Context, in rop.Result[In])
+	// OnEngineEnd is called once the engine produced a result or closed
+	// without one; it is not called when ctx ended first
+	OnEngineEnd func(ctx context.Context, in rop.Result[In], elapsed time.Duration)
+	// OnEmit is called after out was sent on, with the time since OnReceive
+	OnEmit func(ctx context.Context, out rop.Result[Out], latency time.Duration)
+}
+
+// WithLocomotiveHooks sets the hooks of the stages named stage (see
+// WithStageName; "" for stages without a name) carrying In and Out run under
+// ctx; other stages sharing ctx are not observed.
+func WithLocomotiveHooks[In, Out any](ctx context.Context, stage string,
+	hooks LocomotiveHooks[In, Out]) context.Context {
+	return context.WithValue(ctx, locomotiveHooksKey[In, Out]{stage: stage}, hooks)
+}
+
+// GetLocomotiveHooks returns the hooks set for the stage named in ctx carrying In and Out.
+func GetLocomotiveHooks[In, Out any](ctx context.Context) LocomotiveHooks[In, Out] {
+	stage, _ := GetStageName(ctx)
+	hooks, _ := ctx.Value(locomotiveHooksKey[In, Out]{stage: stage}).(LocomotiveHooks[In, Out])
+	return hooks
+}
+
+func (h LocomotiveHooks[In, Out]) receive(ctx context.Context, in rop.Result[In]) time.Time {
+	if h.OnReceive != nil {
+		h.OnReceive(ctx, in)
+	}
+	return time.Now()
+}
+
+func (h LocomotiveHooks[In, Out]) engineStart(ctx context.Context, in rop.Result[In]) time.Time {
+	if h.OnEngineStart != nil {
+		h.OnEngineStart(ctx, in)
+	}
+	return time.Now()
+}
+
+func (h LocomotiveHooks[In, Out]) engineEnd(ctx context.Context, in rop.Result[In], started time.Time) {
+	if h.OnEngineEnd != nil {
+		h.OnEngineEnd(ctx, in, time.Since(started))
+	}
+}
+
+func (h LocomotiveHooks[In, Out]) emit(ctx context.Context, out rop.Result[Out], received time.Time) {
+	if h.OnEmit != nil {
+		h.OnEmit(ctx, out, time.Since(received))
+	}
+}
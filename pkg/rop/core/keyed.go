@@ -0,0 +1,44 @@
+package core
+
+import "sync"
+
+// KeyedMutex serializes work per key while letting different keys proceed in
+// parallel. Locks for idle keys are released, so the key space may be unbounded.
+type KeyedMutex[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+type keyedLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{locks: make(map[K]*keyedLock)}
+}
+
+func (m *KeyedMutex[K]) Lock(key K) {
+	m.mu.Lock()
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	l.mu.Lock()
+}
+
+func (m *KeyedMutex[K]) Unlock(key K) {
+	m.mu.Lock()
+	l := m.locks[key]
+	l.refs--
+	if l.refs == 0 {
+		delete(m.locks, key)
+	}
+	m.mu.Unlock()
+
+	l.mu.Unlock()
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// KV is a map entry carried through a pipeline.
+type KV[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// ToChanFromMap emits the entries of m as successes, in map order. The
+// entries are taken when it is called, so m may change afterwards.
+func ToChanFromMap[K comparable, V any](ctx context.Context, m map[K]V) <-chan rop.Result[KV[K, V]] {
+	entries := make([]KV[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, KV[K, V]{Key: k, Value: v})
+	}
+	return ToChanManyResults(ctx, entries)
+}
+
+// FromChanToMap collects the successful entries of out into a map, a later
+// entry replacing an earlier one with the same key. The errors of the other
+// results are joined into the returned error, along with the cause of a ctx
+// cancel that left the map partial.
+func FromChanToMap[K comparable, V any](ctx context.Context, out <-chan rop.Result[KV[K, V]]) (map[K]V, error) {
+	res := make(map[K]V)
+	results, err := FromChanManySized(ctx, out, 0)
+	var errs []error
+
+	for _, r := range results {
+		if r.IsSuccess() {
+			res[r.Result().Key] = r.Result().Value
+			continue
+		}
+		errs = append(errs, r.Err())
+	}
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return res, errors.Join(errs...)
+}
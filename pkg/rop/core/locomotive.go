@@ -16,10 +16,24 @@ func Locomotive[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
 	handlers CancellationHandlers[In, Out],
 	onSuccess func(ctx context.Context, in rop.Result[Out]), wg *sync.WaitGroup) {
+	LocomotiveUntil(ctx, inputCh, outCh, engine, handlers, onSuccess, nil, wg)
+}
+
+// LocomotiveUntil is Locomotive that also returns, without running any cancel
+// handler, once stop is closed and the current item has been delivered.
+func LocomotiveUntil[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In], outCh chan<- rop.Result[Out],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	handlers CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), stop <-chan struct{}, wg *sync.WaitGroup) {
 	defer wg.Done()
 
+	successOnly := GetEmitMode(ctx, EmitAll) == EmitSuccessOnly
+	hooks := GetLocomotiveHooks[In, Out](ctx)
+
 	for {
 		select {
+		case <-stop:
+			return
 		case <-ctx.Done():
 			if handlers.OnCancel != nil {
 				handlers.OnCancel(ctx, inputCh, outCh)
@@ -30,35 +44,75 @@ func Locomotive[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 				return
 			}
 
-			select {
-			case <-ctx.Done():
-				if handlers.OnCancelUnprocessed != nil {
-					handlers.OnCancelUnprocessed(ctx, in, outCh)
-				}
+			if !carry(ctx, in, outCh, engine, handlers, onSuccess, successOnly, hooks) {
 				if handlers.OnCancel != nil {
 					handlers.OnCancel(ctx, inputCh, outCh)
 				}
 				return
-			case pr, running := <-engine(ctx, in):
-				if !running {
-					return
-				}
+			}
+		}
+	}
+}
 
-				select {
-				case <-ctx.Done():
-					//outCh <- pr // onCancelProcessed possible duplicate!
-					if handlers.OnCancelProcessed != nil {
-						handlers.OnCancelProcessed(ctx, in, pr, outCh)
-					}
-					if handlers.OnCancel != nil {
-						handlers.OnCancel(ctx, inputCh, outCh)
-					}
-					return
-				case outCh <- pr:
-					if onSuccess != nil {
-						onSuccess(ctx, pr)
-					}
-				}
+// carry runs one item through engine and sends its result to outCh. It
+// reports false when ctx ended first, after the item-level cancel handlers ran.
+func carry[In, Out any](ctx context.Context, in rop.Result[In], outCh chan<- rop.Result[Out],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	handlers CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), successOnly bool,
+	hooks LocomotiveHooks[In, Out]) bool {
+
+	received := hooks.receive(ctx, in)
+	started := hooks.engineStart(ctx, in)
+	select {
+	case <-ctx.Done():
+		if handlers.OnCancelUnprocessed != nil {
+			handlers.OnCancelUnprocessed(ctx, in, outCh)
+		}
+		return false
+	case pr, running := <-engine(ctx, in):
+		hooks.engineEnd(ctx, in, started)
+		if !running {
+			// an engine may emit nothing for an item it filters out;
+			// without a cancellation the line goes on with the next item
+			return ctx.Err() == nil
+		}
+
+		select {
+		case <-ctx.Done():
+			//outCh <- pr // onCancelProcessed possible duplicate!
+			if handlers.OnCancelProcessed != nil {
+				handlers.OnCancelProcessed(ctx, in, pr, outCh)
+			}
+			return false
+		case outCh <- pr:
+			hooks.emit(ctx, pr, received)
+			if onSuccess != nil && (!successOnly || pr.IsSuccess()) {
+				onSuccess(ctx, pr)
+			}
+		}
+	}
+	return true
+}
+
+// OnEmitted builds a Locomotive onSuccess callback that dispatches on the
+// outcome of each emitted result. Nil callbacks are skipped.
+func OnEmitted[Out any](onSuccess func(ctx context.Context, r Out),
+	onFailure func(ctx context.Context, err error),
+	onCancel func(ctx context.Context, err error)) func(ctx context.Context, in rop.Result[Out]) {
+	return func(ctx context.Context, in rop.Result[Out]) {
+		switch {
+		case in.IsSuccess():
+			if onSuccess != nil {
+				onSuccess(ctx, in.Result())
+			}
+		case in.IsCancel():
+			if onCancel != nil {
+				onCancel(ctx, in.Err())
+			}
+		default:
+			if onFailure != nil {
+				onFailure(ctx, in.Err())
 			}
 		}
 	}
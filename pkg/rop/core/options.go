@@ -1,12 +1,42 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type OptionKey string
 
 const (
 	ProcessOptionKey OptionKey = "process_options"
 	WorkerOptionKey  OptionKey = "worker_options"
+	EmitOptionKey    OptionKey = "emit_options"
+	BufferOptionKey  OptionKey = "buffer_options"
+	ExecOptionKey    OptionKey = "execution_options"
+	TimeoutOptionKey OptionKey = "stage_timeout_options"
+	RecoverOptionKey OptionKey = "recover_options"
+)
+
+// EmitMode selects which emitted results trigger the Locomotive onSuccess callback.
+type EmitMode int
+
+const (
+	// EmitAll fires the callback for every emitted result, including passed-through failures
+	EmitAll EmitMode = iota
+	// EmitSuccessOnly fires the callback only for successful results
+	EmitSuccessOnly
+)
+
+// ExecutionMode selects how mass runs a per-item stage.
+type ExecutionMode int
+
+const (
+	// ExecuteAsync runs every item in its own goroutine pair, so a canceled
+	// item is abandoned immediately even while its function still runs
+	ExecuteAsync ExecutionMode = iota
+	// ExecuteFused runs the item function inline in the calling line, avoiding
+	// per-item goroutines and channels; cancellation is observed around the call
+	ExecuteFused
 )
 
 type MaxLimitOption struct {
@@ -20,6 +50,56 @@ type ProcessOptions struct {
 	ProcessRemaining bool
 }
 
+type EmitOptions struct {
+	Mode EmitMode
+}
+
+type ExecutionOptions struct {
+	Mode ExecutionMode
+}
+
+// RecoverOptions turns panics in mass stage functions into failures.
+type RecoverOptions struct {
+	RecoverPanics bool
+}
+
+// StageTimeoutOptions bounds every single item of a mass stage by Timeout.
+type StageTimeoutOptions struct {
+	Timeout time.Duration
+}
+
+// OverflowPolicy decides what a bounded buffer between stages does when it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock holds the producer back until there is room
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest evicts the oldest buffered result to make room
+	OverflowDropOldest
+	// OverflowDropNewest discards the result that does not fit
+	OverflowDropNewest
+	// OverflowFail replaces the result that does not fit with a failure
+	// carrying ErrBufferFull, so the loss is visible downstream
+	OverflowFail
+)
+
+// BufferOptions sets the capacity of the output channel a stage creates
+// (0 keeps it unbuffered) and what happens when it is full.
+type BufferOptions struct {
+	Size   int
+	Policy OverflowPolicy
+}
+
+// ChannelSize is the capacity to create a stage output with when it is passed
+// through mass.Buffered: Size for OverflowBlock, 0 for the other policies,
+// whose buffer is the queue of mass.Buffering.
+func (o BufferOptions) ChannelSize() int {
+	if o.Policy != OverflowBlock || o.Size < 0 {
+		return 0
+	}
+	return o.Size
+}
+
 func WithProcessOptions(ctx context.Context, processRemaining bool) context.Context {
 	return context.WithValue(ctx, ProcessOptionKey, ProcessOptions{ProcessRemaining: processRemaining})
 }
@@ -43,3 +123,72 @@ func IsProcessRemainingEnabled(ctx context.Context, defaultProcessRemaining bool
 	}
 	return defaultProcessRemaining
 }
+
+func WithEmitOptions(ctx context.Context, mode EmitMode) context.Context {
+	return context.WithValue(ctx, EmitOptionKey, EmitOptions{Mode: mode})
+}
+
+func GetEmitMode(ctx context.Context, defaultMode EmitMode) EmitMode {
+	options, ok := ctx.Value(EmitOptionKey).(EmitOptions)
+	if ok {
+		return options.Mode
+	}
+	return defaultMode
+}
+
+func WithBufferOptions(ctx context.Context, size int) context.Context {
+	return WithBufferPolicy(ctx, size, OverflowBlock)
+}
+
+func WithBufferPolicy(ctx context.Context, size int, policy OverflowPolicy) context.Context {
+	return context.WithValue(ctx, BufferOptionKey, BufferOptions{Size: size, Policy: policy})
+}
+
+func GetBufferSize(ctx context.Context, defaultSize int) int {
+	options, ok := ctx.Value(BufferOptionKey).(BufferOptions)
+	if ok && options.Size >= 0 {
+		return options.Size
+	}
+	return defaultSize
+}
+
+func WithExecutionOptions(ctx context.Context, mode ExecutionMode) context.Context {
+	return context.WithValue(ctx, ExecOptionKey, ExecutionOptions{Mode: mode})
+}
+
+func GetExecutionMode(ctx context.Context, defaultMode ExecutionMode) ExecutionMode {
+	options, ok := ctx.Value(ExecOptionKey).(ExecutionOptions)
+	if ok {
+		return options.Mode
+	}
+	return defaultMode
+}
+
+func WithStageTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, TimeoutOptionKey, StageTimeoutOptions{Timeout: timeout})
+}
+
+func GetStageTimeout(ctx context.Context) time.Duration {
+	options, ok := ctx.Value(TimeoutOptionKey).(StageTimeoutOptions)
+	if ok {
+		return options.Timeout
+	}
+	return 0
+}
+
+func WithRecoverOptions(ctx context.Context, recoverPanics bool) context.Context {
+	return context.WithValue(ctx, RecoverOptionKey, RecoverOptions{RecoverPanics: recoverPanics})
+}
+
+func IsRecoverEnabled(ctx context.Context, defaultRecover bool) bool {
+	options, ok := ctx.Value(RecoverOptionKey).(RecoverOptions)
+	if ok {
+		return options.RecoverPanics
+	}
+	return defaultRecover
+}
+
+func GetBufferOptions(ctx context.Context) BufferOptions {
+	options, _ := ctx.Value(BufferOptionKey).(BufferOptions)
+	return options
+}
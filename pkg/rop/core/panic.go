@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+const PanicKey OptionKey = "panic_handler"
+
+// WithPanicHandler makes every goroutine spawned through Go for ctx recover
+// its panics and hand them to onPanic instead of crashing the process.
+func WithPanicHandler(ctx context.Context, onPanic func(p *rop.PanicError)) context.Context {
+	return context.WithValue(ctx, PanicKey, onPanic)
+}
+
+func GetPanicHandler(ctx context.Context) (func(p *rop.PanicError), bool) {
+	onPanic, ok := ctx.Value(PanicKey).(func(p *rop.PanicError))
+	return onPanic, ok
+}
+
+func recovering(f func(), onPanic func(p *rop.PanicError)) func() {
+	return func() {
+		defer func() {
+			if v := recover(); v != nil {
+				onPanic(rop.NewPanicError(v))
+			}
+		}()
+		f()
+	}
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+const ProgressOptionKey OptionKey = "progress_options"
+
+// Progress is a snapshot of a stage's throughput handed to the progress callback.
+type Progress struct {
+	// Stage is the name of the reporting stage (see WithStageName), if any
+	Stage     string
+	Processed int64
+	Succeeded int64
+	Failed    int64
+	Canceled  int64
+	Elapsed   time.Duration
+	// Rate is the number of processed items per second since the stage started
+	Rate float64
+	// Done is set on the final report, once the stage has finished
+	Done bool
+}
+
+// ProgressOptions reports progress every Every items and/or every Interval
+// (zero disables either trigger). A final report is always made.
+type ProgressOptions struct {
+	Every      int64
+	Interval   time.Duration
+	OnProgress func(ctx context.Context, p Progress)
+}
+
+func WithProgressOptions(ctx context.Context, every int64, interval time.Duration,
+	onProgress func(ctx context.Context, p Progress)) context.Context {
+	return context.WithValue(ctx, ProgressOptionKey, ProgressOptions{
+		Every: every, Interval: interval, OnProgress: onProgress})
+}
+
+func GetProgressOptions(ctx context.Context) (ProgressOptions, bool) {
+	options, ok := ctx.Value(ProgressOptionKey).(ProgressOptions)
+	return options, ok && options.OnProgress != nil
+}
+
+// ProgressTracker counts the outcomes of one stage and reports them according
+// to the ProgressOptions of its context. A nil tracker ignores every call.
+type ProgressTracker struct {
+	options ProgressOptions
+	started time.Time
+	stop    chan struct{}
+
+	mu       sync.Mutex
+	progress Progress
+	finished bool
+}
+
+// NewProgressTracker starts tracking a stage, or returns nil when ctx carries no ProgressOptions.
+func NewProgressTracker(ctx context.Context) *ProgressTracker {
+	options, ok := GetProgressOptions(ctx)
+	if !ok {
+		return nil
+	}
+
+	p := &ProgressTracker{options: options, started: time.Now(), stop: make(chan struct{})}
+	p.progress.Stage, _ = GetStageName(ctx)
+	if options.Interval > 0 {
+		Go(ctx, func() {
+			ticker := time.NewTicker(options.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					p.report(ctx, false)
+				case <-p.stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+	return p
+}
+
+func (p *ProgressTracker) Record(ctx context.Context, success, canceled bool) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.progress.Processed++
+	switch {
+	case success:
+		p.progress.Succeeded++
+	case canceled:
+		p.progress.Canceled++
+	default:
+		p.progress.Failed++
+	}
+	due := p.options.Every > 0 && p.progress.Processed%p.options.Every == 0
+	p.mu.Unlock()
+
+	if due {
+		p.report(ctx, false)
+	}
+}
+
+// Finish stops interval reporting and makes the final report.
+func (p *ProgressTracker) Finish(ctx context.Context) {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	p.report(ctx, true)
+}
+
+func (p *ProgressTracker) report(ctx context.Context, done bool) {
+	// holding the lock while calling back keeps reports ordered and non-concurrent
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.finished {
+		return
+	}
+	p.finished = done
+
+	snapshot := p.progress
+	snapshot.Elapsed = time.Since(p.started)
+	if secs := snapshot.Elapsed.Seconds(); secs > 0 {
+		snapshot.Rate = float64(snapshot.Processed) / secs
+	}
+	snapshot.Done = done
+	p.options.OnProgress(ctx, snapshot)
+}
+
+// RecordResult is a Locomotive onSuccess callback recording every emitted result
+// in p (nil when p is nil). Under EmitSuccessOnly only successes are recorded.
+func RecordResult[T any](p *ProgressTracker) func(ctx context.Context, r rop.Result[T]) {
+	if p == nil {
+		return nil
+	}
+	return func(ctx context.Context, r rop.Result[T]) {
+		p.Record(ctx, r.IsSuccess(), r.IsCancel())
+	}
+}
+
+// Track passes in through unchanged, recording every result in p and making
+// the final report once in closes.
+func Track[T any](ctx context.Context, in <-chan rop.Result[T], p *ProgressTracker) <-chan rop.Result[T] {
+	out := make(chan rop.Result[T])
+
+	Go(ctx, func() {
+		defer close(out)
+		defer p.Finish(ctx)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-in:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- r:
+					p.Record(ctx, r.IsSuccess(), r.IsCancel())
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out
+}
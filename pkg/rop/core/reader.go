@@ -0,0 +1,52 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// ReaderLinesOptions configures FromReaderLines.
+type ReaderLinesOptions struct {
+	// MaxLineLength is the longest line accepted, bufio.MaxScanTokenSize when 0;
+	// a longer one fails with bufio.ErrTooLong
+	MaxLineLength int
+}
+
+// FromReaderLines emits the lines of r, without their line endings, as they
+// are read. A read error (including a line over the maximum) is emitted as a
+// failure and ends the stream, as does ctx being done.
+func FromReaderLines(ctx context.Context, r io.Reader, opts ReaderLinesOptions) <-chan rop.Result[string] {
+	out := make(chan rop.Result[string])
+
+	maxLength := opts.MaxLineLength
+	if maxLength <= 0 {
+		maxLength = bufio.MaxScanTokenSize
+	}
+
+	Go(ctx, func() {
+		defer close(out)
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, min(maxLength, 4096)), maxLength)
+
+		for scanner.Scan() {
+			select {
+			case out <- rop.Success(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			select {
+			case out <- rop.Fail[string](err):
+			case <-ctx.Done():
+			}
+		}
+	})
+
+	return out
+}
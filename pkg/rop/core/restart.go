@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+const RestartKey OptionKey = "restart_policy"
+
+// RestartPolicy restarts a stage line (Locomotive or dispatcher) that died of a
+// panic, up to MaxRestarts times per line, waiting Backoff(restart) first.
+type RestartPolicy struct {
+	MaxRestarts int
+	Backoff     Backoff
+	// OnRestart, when set, is called before every restart
+	OnRestart func(ctx context.Context, workerID int, p *rop.PanicError)
+	// OnGiveUp, when set, is called when a line panics with no restart left
+	OnGiveUp func(ctx context.Context, workerID int, p *rop.PanicError)
+}
+
+// WithRestartPolicy makes the lines started by StartLines under ctx survive
+// panics per policy. The item a line was carrying when it panicked is lost.
+func WithRestartPolicy(ctx context.Context, policy RestartPolicy) context.Context {
+	return context.WithValue(ctx, RestartKey, policy)
+}
+
+func GetRestartPolicy(ctx context.Context) (RestartPolicy, bool) {
+	policy, ok := ctx.Value(RestartKey).(RestartPolicy)
+	return policy, ok
+}
+
+// restarting runs line, running it again per the restart policy of ctx while
+// it panics. Without a policy line runs once and its panic propagates.
+func restarting(ctx context.Context, workerID int, line func()) {
+	policy, ok := GetRestartPolicy(ctx)
+	if !ok {
+		line()
+		return
+	}
+
+	for restart := 1; ; restart++ {
+		p := catchPanic(line)
+		if p == nil || ctx.Err() != nil {
+			return
+		}
+		if restart > policy.MaxRestarts {
+			if policy.OnGiveUp != nil {
+				policy.OnGiveUp(ctx, workerID, p)
+			}
+			return
+		}
+
+		if policy.OnRestart != nil {
+			policy.OnRestart(ctx, workerID, p)
+		}
+		if policy.Backoff != nil {
+			if Sleep(ctx, policy.Backoff(restart)) != nil {
+				return
+			}
+		}
+	}
+}
+
+func catchPanic(f func()) (p *rop.PanicError) {
+	defer func() {
+		if v := recover(); v != nil {
+			p = rop.NewPanicError(v)
+		}
+	}()
+	f()
+	return nil
+}
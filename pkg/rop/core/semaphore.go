@@ -0,0 +1,231 @@
+package core
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+const SemaphoreKey OptionKey = "semaphore"
+
+// Semaphore is a weighted semaphore shared by stages to bound the number of
+// items executing at once across a whole pipeline. Waiters are served in order.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire takes n tokens, blocking until they are free or ctx is done.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	w := semaphoreWaiter{n: n, ready: make(chan struct{})}
+	elem := s.waiters.PushBack(w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-w.ready:
+			// acquired just as ctx ended: hand the tokens back
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			front := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			if front {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return context.Cause(ctx)
+	}
+}
+
+// Release returns n tokens taken by Acquire.
+func (s *Semaphore) Release(n int64) {
+	s.mu.Lock()
+	s.cur -= n
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+func (s *Semaphore) notifyWaiters() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			return
+		}
+		w := next.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			return
+		}
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
+
+type SemaphoreOptions struct {
+	Sem    *Semaphore
+	Weight int64
+}
+
+// WithSemaphore switches Run/Turnout from a fixed count of Locomotive lines to
+// a single dispatcher per stage: every item takes weight tokens of sem while its
+// engine runs, and lines only caps the items a stage keeps in flight. Sharing
+// sem between stages bounds the concurrency of the whole pipeline.
+func WithSemaphore(ctx context.Context, sem *Semaphore, weight int64) context.Context {
+	if weight < 1 {
+		weight = 1
+	}
+	return context.WithValue(ctx, SemaphoreKey, SemaphoreOptions{Sem: sem, Weight: weight})
+}
+
+func GetSemaphore(ctx context.Context) (SemaphoreOptions, bool) {
+	options, ok := ctx.Value(SemaphoreKey).(SemaphoreOptions)
+	return options, ok && options.Sem != nil
+}
+
+// StartLines starts the workers of a stage: lines Locomotives, or a Dispatch
+// when ctx carries a semaphore, running the worker hooks of ctx around each
+// and restarting them per its restart policy.
+// wg is done once all of them have returned.
+func StartLines[In, Out any](ctx context.Context, lines int, inputCh <-chan rop.Result[In],
+	outCh chan<- rop.Result[Out],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	handlers CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), wg *sync.WaitGroup) {
+
+	if options, ok := GetSemaphore(ctx); ok {
+		wg.Add(1)
+		Go(ctx, func() {
+			defer wg.Done()
+			lineCtx, stop := beginWorker(ctx, 0)
+			defer stop()
+
+			// stop only once the items of the dispatcher are done with the state
+			items := &sync.WaitGroup{}
+			restarting(ctx, 0, func() {
+				items.Add(1)
+				Dispatch(lineCtx, inputCh, outCh, semaphored(engine, options), handlers, onSuccess, lines, items)
+			})
+			items.Wait()
+		})
+		return
+	}
+
+	for i := range lines {
+		wg.Add(1)
+		Go(ctx, func() {
+			defer wg.Done()
+			lineCtx, stop := beginWorker(ctx, i)
+			defer stop()
+
+			restarting(ctx, i, func() {
+				line := &sync.WaitGroup{}
+				line.Add(1)
+				Locomotive(lineCtx, inputCh, outCh, engine, handlers, onSuccess, line)
+			})
+		})
+	}
+}
+
+// Dispatch reads inputCh in one goroutine and carries every item in a goroutine
+// of its own, keeping at most inFlight of them alive. Item goroutines are added
+// to wg, so wg is done only after the last of them has returned.
+func Dispatch[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In], outCh chan<- rop.Result[Out],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	handlers CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), inFlight int, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if inFlight < 1 {
+		inFlight = 1
+	}
+	slots := make(chan struct{}, inFlight)
+	successOnly := GetEmitMode(ctx, EmitAll) == EmitSuccessOnly
+	hooks := GetLocomotiveHooks[In, Out](ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			if handlers.OnCancel != nil {
+				handlers.OnCancel(ctx, inputCh, outCh)
+			}
+			return
+		case in, ok := <-inputCh:
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				if handlers.OnCancelUnprocessed != nil {
+					handlers.OnCancelUnprocessed(ctx, in, outCh)
+				}
+				if handlers.OnCancel != nil {
+					handlers.OnCancel(ctx, inputCh, outCh)
+				}
+				return
+			case slots <- struct{}{}:
+			}
+
+			wg.Add(1)
+			Go(ctx, func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				carry(ctx, in, outCh, engine, handlers, onSuccess, successOnly, hooks)
+			})
+		}
+	}
+}
+
+// semaphored holds the semaphore tokens only while engine runs, not while its
+// result waits for the next stage, so stages sharing a semaphore cannot starve
+// each other.
+func semaphored[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	options SemaphoreOptions) func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+
+		if err := options.Sem.Acquire(ctx, options.Weight); err != nil {
+			out <- rop.Cancel[Out](err)
+			close(out)
+			return out
+		}
+
+		Go(ctx, func() {
+			defer close(out)
+			pr, ok := <-engine(ctx, input)
+			options.Sem.Release(options.Weight)
+			if ok {
+				out <- pr
+			}
+		})
+
+		return out
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"context"
+	"iter"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// FromSeq emits each value of seq as a success until seq ends or ctx is done.
+func FromSeq[T any](ctx context.Context, seq iter.Seq[T]) <-chan rop.Result[T] {
+	out := make(chan rop.Result[T])
+
+	Go(ctx, func() {
+		defer close(out)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		for v := range seq {
+			select {
+			case out <- rop.Success(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+// ToSeq ranges over results: a success yields its value with a nil error,
+// a failure or cancel the zero value with its error. When ctx is done before
+// results closes it yields the ctx cause last. Breaking out of the loop
+// leaves results unread, so cancel ctx to stop its producer.
+func ToSeq[T any](ctx context.Context, results <-chan rop.Result[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			select {
+			case <-ctx.Done():
+				yield(zero, context.Cause(ctx))
+				return
+			case r, ok := <-results:
+				if !ok {
+					return
+				}
+
+				if r.IsSuccess() {
+					if !yield(r.Result(), nil) {
+						return
+					}
+				} else if !yield(zero, r.Err()) {
+					return
+				}
+			}
+		}
+	}
+}
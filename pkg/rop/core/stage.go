@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+const StageKey OptionKey = "stage_name"
+
+// StageError attributes a failure or cancel to the named stage that produced it.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("stage %q: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// WithStageName names the stage running under ctx; see AttributeStage.
+func WithStageName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, StageKey, name)
+}
+
+func GetStageName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(StageKey).(string)
+	return name, ok && name != ""
+}
+
+// FailedStage returns the name of the stage a failure was attributed to.
+func FailedStage(err error) (string, bool) {
+	var stageErr *StageError
+	if errors.As(err, &stageErr) {
+		return stageErr.Stage, true
+	}
+	return "", false
+}
+
+// AttributeStage wraps the error of out in a *StageError naming the stage of
+// ctx, when this stage turned a successful input into a failure or cancel.
+// Results passed through from earlier stages are left as they are.
+func AttributeStage[In, Out any](ctx context.Context, input rop.Result[In], out rop.Result[Out]) rop.Result[Out] {
+	name, ok := GetStageName(ctx)
+	if !ok || !input.IsSuccess() || out.IsSuccess() {
+		return out
+	}
+	if _, attributed := FailedStage(out.Err()); attributed {
+		return out
+	}
+
+	err := &StageError{Stage: name, Err: out.Err()}
+	if out.IsCancel() {
+		return rop.Cancel[Out](err)
+	}
+	return rop.Fail[Out](err)
+}
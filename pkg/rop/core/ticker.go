@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// FromTicker calls gen on every tick of interval and emits what it returns,
+// a success or a failure for its error, until ctx is done. As with
+// time.Ticker, ticks are dropped while a result waits for its reader, so a
+// slow pipeline polls less often rather than falling behind.
+func FromTicker[T any](ctx context.Context, interval time.Duration,
+	gen func(ctx context.Context, tick time.Time) (T, error)) <-chan rop.Result[T] {
+	out := make(chan rop.Result[T])
+
+	Go(ctx, func() {
+		defer close(out)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case tick := <-ticker.C:
+				v, err := gen(ctx, tick)
+				r := rop.Success(v)
+				if err != nil {
+					r = rop.Fail[T](err)
+				}
+
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrMissingTrailer = errors.New("stream ended without trailer")
+
+// Trailer is end-of-stream metadata sent by a source after its last item.
+type Trailer struct {
+	Count    int64
+	Checksum uint64
+	// Complete is false when the source stopped early because ctx was done
+	Complete bool
+}
+
+type TruncatedError struct {
+	Expected Trailer
+	Received int64
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("stream truncated: expected %d item(s), received %d", e.Expected.Count, e.Received)
+}
+
+type ChecksumError struct {
+	Expected uint64
+	Received uint64
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("stream checksum mismatch: expected %x, received %x", e.Expected, e.Received)
+}
+
+// WithTrailer forwards every item of in and, once in is drained, sends a
+// Trailer with the item count and (when sum is set) an order-independent
+// checksum made of the sum of sum(item) over all items.
+func WithTrailer[T any](ctx context.Context, in <-chan T, sum func(v T) uint64) (<-chan T, <-chan Trailer) {
+	out := make(chan T)
+	trailerCh := make(chan Trailer, 1)
+
+	Go(ctx, func() {
+		defer close(trailerCh)
+
+		trailer := Trailer{}
+		func() {
+			defer close(out)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-in:
+					if !ok {
+						trailer.Complete = true
+						return
+					}
+
+					select {
+					case out <- v:
+						trailer.Count++
+						if sum != nil {
+							trailer.Checksum += sum(v)
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+
+		trailerCh <- trailer
+	})
+
+	return out, trailerCh
+}
+
+// VerifyTrailer forwards every item of in and, once in is closed, checks the
+// received count (and checksum when sum is set) against the source trailer.
+// The error channel yields exactly one value (nil when the stream is intact).
+func VerifyTrailer[T any](ctx context.Context, in <-chan T, trailer <-chan Trailer,
+	sum func(v T) uint64) (<-chan T, <-chan error) {
+
+	out := make(chan T)
+	errCh := make(chan error, 1)
+
+	Go(ctx, func() {
+		defer close(errCh)
+
+		var received int64
+		var checksum uint64
+		func() {
+			defer close(out)
+
+			for v := range in {
+				select {
+				case out <- v:
+					received++
+					if sum != nil {
+						checksum += sum(v)
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		var expected Trailer
+		var ok bool
+		select {
+		case expected, ok = <-trailer:
+		case <-ctx.Done():
+			errCh <- context.Cause(ctx)
+			return
+		}
+
+		switch {
+		case !ok:
+			errCh <- ErrMissingTrailer
+		case !expected.Complete || expected.Count != received:
+			errCh <- &TruncatedError{Expected: expected, Received: received}
+		case sum != nil && expected.Checksum != checksum:
+			errCh <- &ChecksumError{Expected: expected.Checksum, Received: checksum}
+		default:
+			errCh <- nil
+		}
+	})
+
+	return out, errCh
+}
@@ -0,0 +1,76 @@
+package core
+
+import "context"
+
+const (
+	WorkerHooksKey OptionKey = "worker_hooks"
+	WorkerKey      OptionKey = "worker"
+)
+
+// WorkerHooks are called by every line of a stage when it starts and stops.
+// The state OnWorkerStart returns is private to that line and reaches its
+// engine through WorkerState, so lines can hold connections or buffers
+// without locks. With WithSemaphore a stage has one dispatcher instead of
+// lines: the hooks run once for it and its items share the state.
+type WorkerHooks[S any] struct {
+	OnWorkerStart func(ctx context.Context, workerID int) S
+	OnWorkerStop  func(ctx context.Context, workerID int, state S)
+}
+
+type worker[S any] struct {
+	id    int
+	state S
+}
+
+type workerLifecycle interface {
+	begin(ctx context.Context, workerID int) (context.Context, func())
+}
+
+func (h WorkerHooks[S]) begin(ctx context.Context, workerID int) (context.Context, func()) {
+	var state S
+	if h.OnWorkerStart != nil {
+		state = h.OnWorkerStart(ctx, workerID)
+	}
+	lineCtx := context.WithValue(ctx, WorkerKey, worker[S]{id: workerID, state: state})
+
+	return lineCtx, func() {
+		if h.OnWorkerStop != nil {
+			h.OnWorkerStop(ctx, workerID, state)
+		}
+	}
+}
+
+// WithWorkerHooks sets the worker hooks of the stage run under ctx. As the
+// hooks would apply to every stage sharing ctx, set them on a stage's own ctx.
+func WithWorkerHooks[S any](ctx context.Context, hooks WorkerHooks[S]) context.Context {
+	return context.WithValue(ctx, WorkerHooksKey, workerLifecycle(hooks))
+}
+
+// WorkerState returns the state the running line got from OnWorkerStart.
+func WorkerState[S any](ctx context.Context) (S, bool) {
+	w, ok := ctx.Value(WorkerKey).(worker[S])
+	return w.state, ok
+}
+
+// WorkerID returns the number (from 0) of the line running under ctx.
+func WorkerID(ctx context.Context) (int, bool) {
+	w, ok := ctx.Value(WorkerKey).(interface{ workerID() int })
+	if !ok {
+		return 0, false
+	}
+	return w.workerID(), true
+}
+
+func (w worker[S]) workerID() int {
+	return w.id
+}
+
+// beginWorker runs the OnWorkerStart hook of ctx (if any) for a line and
+// returns the line ctx along with the matching stop.
+func beginWorker(ctx context.Context, workerID int) (context.Context, func()) {
+	hooks, ok := ctx.Value(WorkerHooksKey).(workerLifecycle)
+	if !ok {
+		return ctx, func() {}
+	}
+	return hooks.begin(ctx, workerID)
+}
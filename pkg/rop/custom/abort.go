@@ -0,0 +1,78 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// ErrorBudget is the share of failures a pipeline may emit before AbortAfter
+// stops it. Zero fields are not checked.
+type ErrorBudget struct {
+	// MaxFailures aborts once more failures than this were emitted
+	MaxFailures int
+	// MaxRatio aborts once the failed share of emitted results exceeds it
+	MaxRatio float64
+	// MinSamples is the number of results to see before MaxRatio applies (100 when 0)
+	MinSamples int
+}
+
+// BudgetError is the cause a pipeline stopped by AbortAfter is canceled with.
+type BudgetError struct {
+	Failures int
+	Seen     int
+	Last     error
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("aborted: error budget exceeded by %d failures in %d results (last: %v)",
+		e.Failures, e.Seen, e.Last)
+}
+
+func (e *BudgetError) Unwrap() error {
+	return e.Last
+}
+
+// AbortAfter derives the ctx to run a pipeline with and a Run/Turnout
+// onSuccess callback watching its emitted results (calling onSuccess, when
+// set, for each). Once failures exceed budget the ctx is canceled with a
+// *BudgetError cause, so a misconfigured job stops early. Cancels are not counted.
+// The returned stop releases the ctx and must be called once the pipeline is done.
+func AbortAfter[T any](ctx context.Context, budget ErrorBudget,
+	onSuccess func(ctx context.Context, in rop.Result[T])) (context.Context,
+	func(ctx context.Context, in rop.Result[T]), context.CancelFunc) {
+
+	if budget.MinSamples <= 0 {
+		budget.MinSamples = 100
+	}
+	abortCtx, cancel := context.WithCancelCause(ctx)
+
+	var mu sync.Mutex
+	var seen, failures int
+	watch := func(ctx context.Context, in rop.Result[T]) {
+		if !in.IsCancel() {
+			mu.Lock()
+			seen++
+			if !in.IsSuccess() {
+				failures++
+			}
+			exceeded := !in.IsSuccess() &&
+				((budget.MaxFailures > 0 && failures > budget.MaxFailures) ||
+					(budget.MaxRatio > 0 && seen >= budget.MinSamples &&
+						float64(failures)/float64(seen) > budget.MaxRatio))
+			cause := &BudgetError{Failures: failures, Seen: seen, Last: in.Err()}
+			mu.Unlock()
+
+			if exceeded {
+				cancel(cause)
+			}
+		}
+		if onSuccess != nil {
+			onSuccess(ctx, in)
+		}
+	}
+
+	return abortCtx, watch, func() { cancel(nil) }
+}
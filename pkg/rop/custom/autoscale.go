@@ -0,0 +1,168 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+type AutoscaleOptions struct {
+	MinLines int
+	MaxLines int
+	// Interval between scaling decisions (100ms when 0)
+	Interval time.Duration
+	// TargetLatency, when set, stops growing (and starts shrinking) while the
+	// average engine latency of the last interval is above it, as more lines
+	// would only add load to an already slow dependency
+	TargetLatency time.Duration
+	// OnScale, when set, is called with the new line count after every change
+	OnScale func(ctx context.Context, lines int)
+}
+
+// RunAutoscaled is Run with a line count adjusted between MinLines and
+// MaxLines once per Interval: a line is added while items wait in inputCh (or
+// all lines are busy) and removed when neither is the case. A removed line
+// finishes its current item first.
+func RunAutoscaled[T any](ctx context.Context, inputCh <-chan rop.Result[T],
+	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
+	handlers core.CancellationHandlers[T, T],
+	onSuccess func(ctx context.Context, in rop.Result[T]), options AutoscaleOptions) <-chan rop.Result[T] {
+
+	if options.MinLines < 1 {
+		options.MinLines = 1
+	}
+	if options.MaxLines < options.MinLines {
+		options.MaxLines = options.MinLines
+	}
+	if options.Interval <= 0 {
+		options.Interval = 100 * time.Millisecond
+	}
+
+	out := make(chan rop.Result[T], core.GetBufferOptions(ctx).ChannelSize())
+	wg := &sync.WaitGroup{}
+	m := &scaleMetrics{}
+
+	// lines holds the stop channel of every running line, newest last
+	var lines []chan struct{}
+	var drained atomic.Bool
+	startLine := func() {
+		stop := make(chan struct{})
+		lines = append(lines, stop)
+		wg.Add(1)
+		core.Go(ctx, func() {
+			line := &sync.WaitGroup{}
+			line.Add(1)
+			core.LocomotiveUntil(ctx, inputCh, out, timed(m, engine), handlers, onSuccess, stop, line)
+
+			select {
+			case <-stop:
+			default:
+				// input closed or canceled: the other lines are about to end too
+				drained.Store(true)
+			}
+			wg.Done()
+		})
+	}
+
+	for range options.MinLines {
+		startLine()
+	}
+
+	wg.Add(1)
+	core.Go(ctx, func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(options.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if drained.Load() {
+				return
+			}
+
+			pressure := len(inputCh) > 0 || m.busy.Load() >= int64(len(lines))
+			latency, measured := m.averageLatency()
+			if options.TargetLatency > 0 && !measured {
+				// no item finished since the last decision: wait for a measure
+				continue
+			}
+			slow := options.TargetLatency > 0 && latency > options.TargetLatency
+
+			switch {
+			case pressure && !slow && len(lines) < options.MaxLines:
+				startLine()
+			case (!pressure || slow) && len(lines) > options.MinLines:
+				close(lines[len(lines)-1])
+				lines = lines[:len(lines)-1]
+			default:
+				continue
+			}
+			if options.OnScale != nil {
+				options.OnScale(ctx, len(lines))
+			}
+		}
+	})
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+	})
+
+	return mass.Buffered(ctx, out)
+}
+
+type scaleMetrics struct {
+	busy    atomic.Int64
+	count   atomic.Int64
+	latency atomic.Int64
+}
+
+// averageLatency returns the average latency since the previous call, false
+// when no item finished in between.
+func (m *scaleMetrics) averageLatency() (time.Duration, bool) {
+	count := m.count.Swap(0)
+	total := m.latency.Swap(0)
+	if count == 0 {
+		return 0, false
+	}
+	return time.Duration(total / count), true
+}
+
+// timed counts the items engine is busy with and their latency, forwarding
+// every result of engine. An item is done once its engine channel closes.
+func timed[In, Out any](m *scaleMetrics, engine func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out]) func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+		m.busy.Add(1)
+		started := time.Now()
+
+		core.Go(ctx, func() {
+			defer close(out)
+			defer m.busy.Add(-1)
+
+			for pr := range engine(ctx, input) {
+				select {
+				case out <- pr:
+				case <-ctx.Done():
+					// keep reading so the engine is not left blocked
+				}
+			}
+			m.latency.Add(int64(time.Since(started)))
+			m.count.Add(1)
+		})
+
+		return out
+	}
+}
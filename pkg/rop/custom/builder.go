@@ -0,0 +1,176 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+// HandlersBuilder assembles core.CancellationHandlers one decision at a time.
+// Each of the three cancel cases has to be decided (dropping is a decision
+// too), so Build reports the ones forgotten instead of silently leaving them nil.
+type HandlersBuilder[In, Out any] struct {
+	handlers    core.CancellationHandlers[In, Out]
+	remaining   bool
+	unprocessed bool
+	processed   bool
+	errs        []error
+}
+
+func NewHandlers[In, Out any]() *HandlersBuilder[In, Out] {
+	return &HandlersBuilder[In, Out]{}
+}
+
+// OnCancelDrain emits the remaining input as cancels (CancelRemainingResults).
+func (b *HandlersBuilder[In, Out]) OnCancelDrain() *HandlersBuilder[In, Out] {
+	return b.OnCancel(CancelRemainingResults[In, Out])
+}
+
+// OnCancelDrop discards the remaining input.
+func (b *HandlersBuilder[In, Out]) OnCancelDrop() *HandlersBuilder[In, Out] {
+	return b.OnCancel(DropRemaining[In, Out]().OnCancel)
+}
+
+func (b *HandlersBuilder[In, Out]) OnCancel(onCancel func(ctx context.Context,
+	inputCh <-chan rop.Result[In], outCh chan<- rop.Result[Out])) *HandlersBuilder[In, Out] {
+
+	b.errs = appendDecision(b.errs, "OnCancel", b.remaining, onCancel != nil)
+	b.remaining = true
+	b.handlers.OnCancel = onCancel
+	return b
+}
+
+// OnUnprocessedCancel emits the item taken but not run as a cancel.
+func (b *HandlersBuilder[In, Out]) OnUnprocessedCancel() *HandlersBuilder[In, Out] {
+	return b.OnUnprocessed(CancelRemainingResult[In, Out])
+}
+
+func (b *HandlersBuilder[In, Out]) OnUnprocessedDrop() *HandlersBuilder[In, Out] {
+	return b.OnUnprocessed(func(context.Context, rop.Result[In], chan<- rop.Result[Out]) {})
+}
+
+func (b *HandlersBuilder[In, Out]) OnUnprocessed(onUnprocessed func(ctx context.Context,
+	unprocessed rop.Result[In], outCh chan<- rop.Result[Out])) *HandlersBuilder[In, Out] {
+
+	b.errs = appendDecision(b.errs, "OnCancelUnprocessed", b.unprocessed, onUnprocessed != nil)
+	b.unprocessed = true
+	b.handlers.OnCancelUnprocessed = onUnprocessed
+	return b
+}
+
+// OnProcessedPass emits a result that was ready when the cancel came as it is.
+func (b *HandlersBuilder[In, Out]) OnProcessedPass() *HandlersBuilder[In, Out] {
+	return b.OnProcessed(EmitProcessedResult[In, Out])
+}
+
+// OnProcessedCancel replaces a result that was ready when the cancel came with a cancel.
+func (b *HandlersBuilder[In, Out]) OnProcessedCancel() *HandlersBuilder[In, Out] {
+	return b.OnProcessed(DrainAsCancelled[In, Out]().OnCancelProcessed)
+}
+
+func (b *HandlersBuilder[In, Out]) OnProcessedDrop() *HandlersBuilder[In, Out] {
+	return b.OnProcessed(func(context.Context, rop.Result[In], rop.Result[Out], chan<- rop.Result[Out]) {})
+}
+
+func (b *HandlersBuilder[In, Out]) OnProcessed(onProcessed func(ctx context.Context, in rop.Result[In],
+	processed rop.Result[Out], outCh chan<- rop.Result[Out])) *HandlersBuilder[In, Out] {
+
+	b.errs = appendDecision(b.errs, "OnCancelProcessed", b.processed, onProcessed != nil)
+	b.processed = true
+	b.handlers.OnCancelProcessed = onProcessed
+	return b
+}
+
+func (b *HandlersBuilder[In, Out]) Build() (core.CancellationHandlers[In, Out], error) {
+	errs := b.errs
+	for _, missing := range []struct {
+		name    string
+		decided bool
+	}{
+		{"OnCancel", b.remaining},
+		{"OnCancelUnprocessed", b.unprocessed},
+		{"OnCancelProcessed", b.processed},
+	} {
+		if !missing.decided {
+			errs = append(errs, fmt.Errorf("%s: %w", missing.name, ErrHandlerMissing))
+		}
+	}
+	return b.handlers, errors.Join(errs...)
+}
+
+var (
+	ErrHandlerMissing = errors.New("handler not set")
+	ErrHandlerTwice   = errors.New("handler set twice")
+	ErrHandlerNil     = errors.New("nil handler")
+)
+
+// appendDecision records a handler set twice (decided) or set to nil.
+func appendDecision(errs []error, name string, decided, valid bool) []error {
+	if decided {
+		errs = append(errs, fmt.Errorf("%s: %w", name, ErrHandlerTwice))
+	}
+	if !valid {
+		errs = append(errs, fmt.Errorf("%s: %w", name, ErrHandlerNil))
+	}
+	return errs
+}
+
+// FinallyBuilder assembles the mass.FinallyHandlers and
+// mass.FinallyCancelHandlers pair taken by Finally.
+type FinallyBuilder[In, Out any] struct {
+	handlers       mass.FinallyHandlers[In, Out]
+	cancelHandlers mass.FinallyCancelHandlers[In, Out]
+	draining       bool
+	errs           []error
+}
+
+// NewFinally starts a FinallyBuilder mapping successes with onSuccess; failures
+// and cancels map to the zero Out unless OnError/OnCancel say otherwise.
+func NewFinally[In, Out any](onSuccess func(ctx context.Context, r In) Out) *FinallyBuilder[In, Out] {
+	b := &FinallyBuilder[In, Out]{handlers: mass.DefaultFinallyHandlers[In, Out](onSuccess)}
+	b.errs = appendDecision(nil, "OnSuccess", false, onSuccess != nil)
+	return b
+}
+
+func (b *FinallyBuilder[In, Out]) OnError(onError func(ctx context.Context, err error) Out) *FinallyBuilder[In, Out] {
+	b.errs = appendDecision(b.errs, "OnError", false, onError != nil)
+	b.handlers.OnError = onError
+	return b
+}
+
+func (b *FinallyBuilder[In, Out]) OnCancel(onCancel func(ctx context.Context, err error) Out) *FinallyBuilder[In, Out] {
+	b.errs = appendDecision(b.errs, "OnCancel", false, onCancel != nil)
+	b.handlers.OnCancel = onCancel
+	return b
+}
+
+// OnBreak maps an input cut off by a cancel to the value emitted for it.
+func (b *FinallyBuilder[In, Out]) OnBreak(onBreak func(ctx context.Context, in rop.Result[In]) Out) *FinallyBuilder[In, Out] {
+	b.errs = appendDecision(b.errs, "OnBreak", b.cancelHandlers.OnBreak != nil, onBreak != nil)
+	b.cancelHandlers.OnBreak = onBreak
+	return b
+}
+
+// OnCancelDrain emits, on cancel, the OnBreak value of every input left and the
+// values finalized but not yet sent (CancelRemainingValue(s)/CancelResult(s)).
+// It requires OnBreak.
+func (b *FinallyBuilder[In, Out]) OnCancelDrain() *FinallyBuilder[In, Out] {
+	b.draining = true
+	b.cancelHandlers.OnCancelValue = CancelRemainingValue[In, Out]
+	b.cancelHandlers.OnCancelValues = CancelRemainingValues[In, Out]
+	b.cancelHandlers.OnCancelResult = CancelResult[Out]
+	b.cancelHandlers.OnCancelResults = CancelResults[Out]
+	return b
+}
+
+func (b *FinallyBuilder[In, Out]) Build() (mass.FinallyHandlers[In, Out], mass.FinallyCancelHandlers[In, Out], error) {
+	errs := b.errs
+	if b.draining && b.cancelHandlers.OnBreak == nil {
+		errs = append(errs, fmt.Errorf("OnBreak: %w (required by OnCancelDrain)", ErrHandlerMissing))
+	}
+	return b.handlers, b.cancelHandlers, errors.Join(errs...)
+}
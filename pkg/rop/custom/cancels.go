@@ -3,12 +3,26 @@ package custom
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
+
 	"github.com/ib-77/rop3/pkg/rop"
 	"github.com/ib-77/rop3/pkg/rop/core"
 )
 
 var ErrCancelled = errors.New("operation cancelled")
 
+// cancelCause is the error of a cancel emitted by the handlers: ErrCancelled
+// wrapping context.Cause(ctx), so consumers can tell a deadline from a manual
+// abort or a parent shutdown while errors.Is(err, ErrCancelled) still holds.
+func cancelCause(ctx context.Context) error {
+	cause := context.Cause(ctx)
+	if cause == nil {
+		return ErrCancelled
+	}
+	return fmt.Errorf("%w: %w", ErrCancelled, cause)
+}
+
 func CancelRemainingResults[In, Out any](ctx context.Context,
 	inputCh <-chan rop.Result[In], outCh chan<- rop.Result[Out]) {
 
@@ -20,7 +34,7 @@ func CancelRemainingResults[In, Out any](ctx context.Context,
 			if in.IsCancel() {
 				outCh <- rop.CancelFrom[In, Out](in)
 			} else {
-				outCh <- rop.Cancel[Out](ErrCancelled)
+				outCh <- rop.Cancel[Out](cancelCause(ctx))
 			}
 		}
 	}
@@ -36,7 +50,7 @@ func CancelRemainingResult[In, Out any](ctx context.Context, in rop.Result[In],
 		if in.IsCancel() {
 			outCh <- rop.CancelFrom[In, Out](in)
 		} else {
-			outCh <- rop.Cancel[Out](ErrCancelled)
+			outCh <- rop.Cancel[Out](cancelCause(ctx))
 		}
 	}
 }
@@ -82,3 +96,131 @@ func CancelRemainingValues[In, Out any](ctx context.Context, inputCh <-chan rop.
 		}
 	}
 }
+
+// DrainAsCancelled turns every item still in the stage on cancel into a cancel
+// result: the remaining input, the item in flight and an item whose result was
+// ready but not yet sent.
+func DrainAsCancelled[In, Out any]() core.CancellationHandlers[In, Out] {
+	return core.CancellationHandlers[In, Out]{
+		OnCancel:            CancelRemainingResults[In, Out],
+		OnCancelUnprocessed: CancelRemainingResult[In, Out],
+		OnCancelProcessed: func(ctx context.Context, in rop.Result[In], _ rop.Result[Out],
+			outCh chan<- rop.Result[Out]) {
+			CancelRemainingResult(ctx, in, outCh)
+		},
+	}
+}
+
+// DropRemaining emits nothing on cancel; the remaining input is read and
+// discarded so upstream stages are not left blocked.
+func DropRemaining[In, Out any]() core.CancellationHandlers[In, Out] {
+	return core.CancellationHandlers[In, Out]{
+		OnCancel: func(ctx context.Context, inputCh <-chan rop.Result[In], _ chan<- rop.Result[Out]) {
+			for range inputCh {
+			}
+		},
+	}
+}
+
+// EmitProcessed is DrainAsCancelled except that an item whose result was
+// ready when the cancel came is emitted as it is.
+func EmitProcessed[In, Out any]() core.CancellationHandlers[In, Out] {
+	handlers := DrainAsCancelled[In, Out]()
+	handlers.OnCancelProcessed = EmitProcessedResult[In, Out]
+	return handlers
+}
+
+func EmitProcessedResult[In, Out any](ctx context.Context, in rop.Result[In],
+	processed rop.Result[Out], outCh chan<- rop.Result[Out]) {
+
+	outCh <- processed
+}
+
+// CancelTotals is what a stage left undone on cancel, see CancelStatsHandlers.
+type CancelTotals struct {
+	// Unprocessed items were taken from the input but not run
+	Unprocessed int64
+	// Processed items had a result that was not sent on
+	Processed int64
+	// Drained items were taken from the input by OnCancel; with an OnCancel
+	// that stops early, one of them may have been dropped unseen
+	Drained int64
+}
+
+type CancelStats struct {
+	unprocessed atomic.Int64
+	processed   atomic.Int64
+	drained     atomic.Int64
+}
+
+func (s *CancelStats) Totals() CancelTotals {
+	return CancelTotals{
+		Unprocessed: s.unprocessed.Load(),
+		Processed:   s.processed.Load(),
+		Drained:     s.drained.Load(),
+	}
+}
+
+// CancelStatsHandlers wraps a cancellation strategy (e.g. DrainAsCancelled())
+// counting the items each handler sees into the returned stats. A nil
+// OnCancel drains the remaining input without emitting it.
+func CancelStatsHandlers[In, Out any](handlers core.CancellationHandlers[In, Out]) (core.CancellationHandlers[In, Out],
+	*CancelStats) {
+
+	stats := &CancelStats{}
+	counted := core.CancellationHandlers[In, Out]{
+		OnCancel: func(ctx context.Context, inputCh <-chan rop.Result[In], outCh chan<- rop.Result[Out]) {
+			if handlers.OnCancel == nil {
+				for range inputCh {
+					stats.drained.Add(1)
+				}
+				return
+			}
+
+			remaining := make(chan rop.Result[In])
+			done := make(chan struct{})
+			stopped := make(chan struct{})
+			core.Go(ctx, func() {
+				defer close(stopped)
+				defer close(remaining)
+				for {
+					select {
+					case <-done:
+						return
+					case r, ok := <-inputCh:
+						if !ok {
+							return
+						}
+						// counted once taken: an item read just as OnCancel
+						// returns is dropped along with the forwarding
+						stats.drained.Add(1)
+						select {
+						case remaining <- r:
+						case <-done:
+							return
+						}
+					}
+				}
+			})
+
+			handlers.OnCancel(ctx, remaining, outCh)
+			close(done)
+			<-stopped
+		},
+		OnCancelUnprocessed: func(ctx context.Context, unprocessed rop.Result[In], outCh chan<- rop.Result[Out]) {
+			stats.unprocessed.Add(1)
+			if handlers.OnCancelUnprocessed != nil {
+				handlers.OnCancelUnprocessed(ctx, unprocessed, outCh)
+			}
+		},
+		OnCancelProcessed: func(ctx context.Context, in rop.Result[In], processed rop.Result[Out],
+			outCh chan<- rop.Result[Out]) {
+			stats.processed.Add(1)
+			if handlers.OnCancelProcessed != nil {
+				handlers.OnCancelProcessed(ctx, in, processed, outCh)
+			}
+		},
+	}
+
+	return counted, stats
+}
@@ -0,0 +1,70 @@
+package custom
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Checkpointer records the IDs of inputs that made it through a pipeline, so a
+// restarted job can skip them.
+type Checkpointer[ID comparable] interface {
+	MarkProcessed(id ID) error
+	// LastCheckpoint returns the most recently marked ID, false when none is
+	LastCheckpoint() (ID, bool)
+}
+
+// Checkpointed builds a Run/Turnout onSuccess callback marking the ID (per
+// idFn) of every successful emitted result in cp before calling onSuccess.
+// A failing MarkProcessed is reported to onError; nil callbacks are skipped.
+func Checkpointed[T any, ID comparable](cp Checkpointer[ID], idFn func(r T) ID,
+	onSuccess func(ctx context.Context, in rop.Result[T]),
+	onError func(ctx context.Context, id ID, err error)) func(ctx context.Context, in rop.Result[T]) {
+
+	return func(ctx context.Context, in rop.Result[T]) {
+		if in.IsSuccess() {
+			id := idFn(in.Result())
+			if err := cp.MarkProcessed(id); err != nil && onError != nil {
+				onError(ctx, id, err)
+			}
+		}
+		if onSuccess != nil {
+			onSuccess(ctx, in)
+		}
+	}
+}
+
+// MemoryCheckpointer is an in-process Checkpointer, e.g. for tests or to be
+// persisted by the caller on shutdown.
+type MemoryCheckpointer[ID comparable] struct {
+	mu        sync.Mutex
+	processed map[ID]struct{}
+	last      ID
+	marked    bool
+}
+
+func NewMemoryCheckpointer[ID comparable]() *MemoryCheckpointer[ID] {
+	return &MemoryCheckpointer[ID]{processed: map[ID]struct{}{}}
+}
+
+func (c *MemoryCheckpointer[ID]) MarkProcessed(id ID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.processed[id] = struct{}{}
+	c.last, c.marked = id, true
+	return nil
+}
+
+func (c *MemoryCheckpointer[ID]) LastCheckpoint() (ID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last, c.marked
+}
+
+func (c *MemoryCheckpointer[ID]) IsProcessed(id ID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.processed[id]
+	return ok
+}
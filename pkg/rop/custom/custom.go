@@ -3,6 +3,7 @@ package custom
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ib-77/rop3/pkg/rop"
 	"github.com/ib-77/rop3/pkg/rop/core"
@@ -12,43 +13,43 @@ import (
 func Run[T any](ctx context.Context, inputCh <-chan rop.Result[T],
 	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
 	handlers core.CancellationHandlers[T, T],
-	onSuccess func(ctx context.Context, in rop.Result[T]), lines int) <-chan rop.Result[T] {
+	onSuccess func(ctx context.Context, in rop.Result[T]), lines int, opts ...core.Option) <-chan rop.Result[T] {
 
-	out := make(chan rop.Result[T])
+	ctx, options := core.ApplyOptions(ctx, opts...)
+	lines = options.Lines(lines)
+
+	out := make(chan rop.Result[T], options.Buffer.ChannelSize())
 	wg := &sync.WaitGroup{}
 
-	for range lines {
-		wg.Add(1)
-		go core.Locomotive(ctx, inputCh, out, engine, handlers, onSuccess, wg)
-	}
+	core.StartLines(ctx, lines, inputCh, out, engine, handlers, onSuccess, wg)
 
-	go func() {
+	core.Go(ctx, func() {
 		wg.Wait()
 		close(out)
-	}()
+	})
 
-	return out
+	return mass.BufferedWith(ctx, out, options.Buffer)
 }
 
 func Turnout[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
 	handlers core.CancellationHandlers[In, Out],
-	onSuccess func(ctx context.Context, in rop.Result[Out]), lines int) <-chan rop.Result[Out] {
+	onSuccess func(ctx context.Context, in rop.Result[Out]), lines int, opts ...core.Option) <-chan rop.Result[Out] {
 
-	out := make(chan rop.Result[Out])
+	ctx, options := core.ApplyOptions(ctx, opts...)
+	lines = options.Lines(lines)
+
+	out := make(chan rop.Result[Out], options.Buffer.ChannelSize())
 	wg := &sync.WaitGroup{}
 
-	for range lines {
-		wg.Add(1)
-		go core.Locomotive(ctx, inputCh, out, engine, handlers, onSuccess, wg)
-	}
+	core.StartLines(ctx, lines, inputCh, out, engine, handlers, onSuccess, wg)
 
-	go func() {
+	core.Go(ctx, func() {
 		wg.Wait()
 		close(out)
-	}()
+	})
 
-	return out
+	return mass.BufferedWith(ctx, out, options.Buffer)
 }
 
 func RunSingle[T any](ctx context.Context, inputCh <-chan rop.Result[T],
@@ -101,6 +102,26 @@ func Tee[T any](sideEffect func(ctx context.Context, r rop.Result[T]),
 	}
 }
 
+// KeyedTee runs sideEffect serialized per key (keyFn of the successful value)
+// while side effects for different keys run in parallel across lines.
+func KeyedTee[T any, K comparable](keyFn func(r T) K,
+	sideEffect func(ctx context.Context, r rop.Result[T]),
+	onCancel func(ctx context.Context, in rop.Result[T])) func(ctx context.Context,
+	input rop.Result[T]) <-chan rop.Result[T] {
+
+	locks := core.NewKeyedMutex[K]()
+	serialized := func(ctx context.Context, r rop.Result[T]) {
+		key := keyFn(r.Result())
+		locks.Lock(key)
+		defer locks.Unlock(key)
+		sideEffect(ctx, r)
+	}
+
+	return func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T] {
+		return mass.Teeing(ctx, input, serialized, onCancel)
+	}
+}
+
 func DoubleTee[T any](sideEffect func(ctx context.Context, r T),
 	sideEffectOnError func(ctx context.Context, err error),
 	sideEffectOnCancel func(ctx context.Context, err error),
@@ -111,6 +132,15 @@ func DoubleTee[T any](sideEffect func(ctx context.Context, r T),
 	}
 }
 
+func Filter[T any](pred func(ctx context.Context, r T) bool,
+	onDrop func(ctx context.Context, in rop.Result[T]),
+	onCancel func(ctx context.Context, in rop.Result[T])) func(ctx context.Context,
+	input rop.Result[T]) <-chan rop.Result[T] {
+	return func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T] {
+		return mass.Filtering(ctx, input, pred, onDrop, onCancel)
+	}
+}
+
 func Try[In, Out any](
 	onTryExecute func(ctx context.Context, r In) (Out, error),
 	onCancel func(ctx context.Context, in rop.Result[In])) func(ctx context.Context,
@@ -120,9 +150,28 @@ func Try[In, Out any](
 	}
 }
 
+func Step[In, Out any](step func(ctx context.Context, input rop.Result[In]) rop.Result[Out],
+	onCancel func(ctx context.Context, in rop.Result[In])) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		return mass.Stepping(ctx, input, step, onCancel)
+	}
+}
+
+// Batch is lite.Batch with the cancel handling of cancelHandlers; without an
+// OnCancelBatch the partial batch is flushed on cancel (mass.FlushRemainingBatch).
+func Batch[T any](ctx context.Context, input <-chan rop.Result[T], size int, maxWait time.Duration,
+	cancelHandlers mass.BatchingCancelHandlers[T]) <-chan rop.Result[[]T] {
+	if cancelHandlers.OnCancelBatch == nil {
+		cancelHandlers.OnCancelBatch = mass.FlushRemainingBatch[T]
+	}
+	return mass.Batching(ctx, input, size, maxWait, cancelHandlers)
+}
+
 func Finally[In, Out any](ctx context.Context, input <-chan rop.Result[In],
 	handlers mass.FinallyHandlers[In, Out],
 	cancelHandlers mass.FinallyCancelHandlers[In, Out],
-	onSuccessResult func(ctx context.Context, out Out)) <-chan Out {
+	onSuccessResult func(ctx context.Context, out Out), opts ...core.Option) <-chan Out {
+	ctx, _ = core.ApplyOptions(ctx, opts...)
 	return mass.Finalizing(ctx, input, handlers, cancelHandlers, onSuccessResult)
 }
@@ -0,0 +1,112 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+var errBadRecord = errors.New("bad record")
+
+func failingFrom(n int) func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+	return Try(func(ctx context.Context, v int) (int, error) {
+		if v >= n {
+			return 0, errBadRecord
+		}
+		return v, nil
+	}, nil)
+}
+
+func endless(ctx context.Context) <-chan rop.Result[int] {
+	in := make(chan rop.Result[int])
+	go func() {
+		defer close(in)
+		for v := 0; ; v++ {
+			select {
+			case in <- rop.Success(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return in
+}
+
+func TestAbortAfter_MaxFailures(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ctx, watch, stop := AbortAfter[int](parent, ErrorBudget{MaxFailures: 3}, nil)
+	defer stop()
+	out := Run(ctx, endless(ctx), failingFrom(10), DropRemaining[int, int](), watch, 1)
+	for range out {
+	}
+
+	var budgetErr *BudgetError
+	if !errors.As(context.Cause(ctx), &budgetErr) {
+		t.Fatalf("expected a *BudgetError cause, got %v", context.Cause(ctx))
+	}
+	if budgetErr.Failures != 4 || budgetErr.Seen != 14 || !errors.Is(budgetErr, errBadRecord) {
+		t.Fatalf("expected the abort on the 4th failure of 14 results, got %+v", budgetErr)
+	}
+	if parent.Err() != nil {
+		t.Fatal("expected only the pipeline ctx canceled")
+	}
+}
+
+func TestAbortAfter_MaxRatio(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// every item fails past 5, the ratio only applies after 10 results
+	ctx, watch, stop := AbortAfter[int](parent, ErrorBudget{MaxRatio: 0.5, MinSamples: 10}, nil)
+	defer stop()
+	out := Run(ctx, endless(ctx), failingFrom(5), DropRemaining[int, int](), watch, 1)
+	for range out {
+	}
+
+	var budgetErr *BudgetError
+	if !errors.As(context.Cause(ctx), &budgetErr) || budgetErr.Seen != 11 || budgetErr.Failures != 6 {
+		t.Fatalf("expected the abort at 6 failures in 11 results, got %v", context.Cause(ctx))
+	}
+}
+
+func TestAbortAfter_WithinBudget(t *testing.T) {
+	t.Parallel()
+
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var emitted int
+	ctx, watch, stop := AbortAfter(parent, ErrorBudget{MaxFailures: 3}, func(ctx context.Context, in rop.Result[int]) {
+		emitted++
+	})
+	defer stop()
+	out := Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), failingFrom(2), core.CancellationHandlers[int, int]{},
+		watch, 1)
+	for range out {
+	}
+
+	if ctx.Err() != nil || emitted != 4 {
+		t.Fatalf("expected no abort and 4 results passed on, got %v and %d", ctx.Err(), emitted)
+	}
+}
+
+func TestAbortAfter_StopReleasesContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, _, stop := AbortAfter[int](context.Background(), ErrorBudget{MaxFailures: 3}, nil)
+	stop()
+
+	if !errors.Is(context.Cause(ctx), context.Canceled) {
+		t.Fatalf("expected the ctx canceled by stop, got %v", context.Cause(ctx))
+	}
+}
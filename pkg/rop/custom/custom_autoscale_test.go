@@ -0,0 +1,140 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRunAutoscaled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var peak, current int
+	onScale := func(ctx context.Context, lines int) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = lines
+		peak = max(peak, lines)
+	}
+
+	engine := Map(func(ctx context.Context, v int) int {
+		time.Sleep(5 * time.Millisecond)
+		return v
+	}, nil)
+
+	in := make(chan rop.Result[int], 40)
+	for v := range 40 {
+		in <- rop.Success(v)
+	}
+
+	out := RunAutoscaled(ctx, in, engine, core.CancellationHandlers[int, int]{}, nil, AutoscaleOptions{
+		MinLines: 1,
+		MaxLines: 4,
+		Interval: 5 * time.Millisecond,
+		OnScale:  onScale,
+	})
+
+	for range 40 {
+		if _, ok := <-out; !ok {
+			t.Fatal("expected 40 results")
+		}
+	}
+
+	// with the backlog gone the lines go back to the minimum
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		shrunk := current == 1
+		mu.Unlock()
+		if shrunk {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(in)
+	for range out {
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if peak != 4 {
+		t.Fatalf("expected the backlog to scale up to 4 lines, peaked at %d", peak)
+	}
+	if current != 1 {
+		t.Fatalf("expected an idle stage scaled down to 1 line, got %d", current)
+	}
+}
+
+func TestRunAutoscaled_TargetLatency(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	peak := 1
+	engine := Map(func(ctx context.Context, v int) int {
+		time.Sleep(10 * time.Millisecond)
+		return v
+	}, nil)
+
+	out := RunAutoscaled(ctx, core.ToChanManyResults(ctx, make([]int, 20)), engine,
+		core.CancellationHandlers[int, int]{}, nil, AutoscaleOptions{
+			MinLines:      2,
+			MaxLines:      8,
+			Interval:      5 * time.Millisecond,
+			TargetLatency: time.Millisecond,
+			OnScale: func(ctx context.Context, lines int) {
+				mu.Lock()
+				defer mu.Unlock()
+				peak = max(peak, lines)
+			},
+		})
+
+	if n := len(core.FromChanMany(ctx, out)); n != 20 {
+		t.Fatalf("expected 20 results, got %d", n)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if peak > 2 {
+		t.Fatalf("expected no growth above the minimum over the target latency, got %d", peak)
+	}
+}
+
+func TestTimed_ForwardsEveryResult(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := &scaleMetrics{}
+	engine := timed(m, func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int])
+		go func() {
+			defer close(out)
+			out <- input
+			out <- input
+		}()
+		return out
+	})
+
+	var got int
+	for r := range engine(ctx, rop.Success(1)) {
+		if !r.IsSuccess() {
+			t.Fatalf("unexpected failure: %v", r.Err())
+		}
+		got++
+	}
+	if got != 2 {
+		t.Fatalf("expected both results forwarded, got %d", got)
+	}
+	if m.busy.Load() != 0 || m.count.Load() != 1 {
+		t.Fatalf("expected one finished item, got busy %d and count %d", m.busy.Load(), m.count.Load())
+	}
+}
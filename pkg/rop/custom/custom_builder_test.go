@@ -0,0 +1,79 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestNewHandlers(t *testing.T) {
+	t.Parallel()
+
+	handlers, err := NewHandlers[int, string]().OnCancelDrain().OnUnprocessedCancel().OnProcessedPass().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := make(chan rop.Result[string], 1)
+	handlers.OnCancelProcessed(context.Background(), rop.Success(1), rop.Success("1"), out)
+	if r := <-out; !r.IsSuccess() || r.Result() != "1" {
+		t.Fatalf("expected the processed result passed, got %v", r)
+	}
+	if handlers.OnCancel == nil || handlers.OnCancelUnprocessed == nil {
+		t.Fatal("expected all handlers set")
+	}
+}
+
+func TestNewHandlers_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewHandlers[int, int]().OnCancelDrop().OnCancelDrain().OnProcessed(nil).Build()
+	if !errors.Is(err, ErrHandlerTwice) || !errors.Is(err, ErrHandlerNil) || !errors.Is(err, ErrHandlerMissing) {
+		t.Fatalf("expected twice, nil and missing handler errors, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "OnCancelUnprocessed") {
+		t.Fatalf("expected the missing handler named, got %v", err)
+	}
+}
+
+func TestNewFinally(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	handlers, cancelHandlers, err := NewFinally(func(ctx context.Context, r int) string {
+		return "ok"
+	}).OnError(func(ctx context.Context, err error) string {
+		return "failed"
+	}).OnBreak(func(ctx context.Context, in rop.Result[int]) string {
+		return "broken"
+	}).OnCancelDrain().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	in := make(chan rop.Result[int], 2)
+	in <- rop.Success(1)
+	in <- rop.Fail[int](errBadRecord)
+	close(in)
+
+	got := core.FromChanMany(ctx, Finally(ctx, in, handlers, cancelHandlers, nil))
+	if len(got) != 2 || got[0] != "ok" || got[1] != "failed" {
+		t.Fatalf("expected ok and failed, got %v", got)
+	}
+}
+
+func TestNewFinally_Validation(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := NewFinally[int, string](nil).OnCancelDrain().Build()
+	if !errors.Is(err, ErrHandlerNil) || !errors.Is(err, ErrHandlerMissing) {
+		t.Fatalf("expected nil OnSuccess and missing OnBreak errors, got %v", err)
+	}
+}
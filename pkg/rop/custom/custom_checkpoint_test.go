@@ -0,0 +1,71 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRun_Checkpointed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		if v == 3 {
+			return 0, errors.New("rejected")
+		}
+		return v, nil
+	}, nil)
+
+	cp := NewMemoryCheckpointer[int]()
+	var emitted atomic.Int32
+	onSuccess := Checkpointed(cp, func(r int) int { return r }, func(ctx context.Context, in rop.Result[int]) {
+		emitted.Add(1)
+	}, nil)
+
+	out := Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine,
+		core.CancellationHandlers[int, int]{}, onSuccess, 1)
+	core.FromChanMany(ctx, out)
+
+	for _, id := range []int{1, 2, 4} {
+		if !cp.IsProcessed(id) {
+			t.Fatalf("expected %d checkpointed", id)
+		}
+	}
+	if cp.IsProcessed(3) {
+		t.Fatal("expected the failed item not checkpointed")
+	}
+	if last, ok := cp.LastCheckpoint(); !ok || last != 4 {
+		t.Fatalf("expected last checkpoint 4, got %d (%v)", last, ok)
+	}
+	if n := emitted.Load(); n != 4 {
+		t.Fatalf("expected onSuccess called for all 4 emits, got %d", n)
+	}
+}
+
+type failingCheckpointer struct{}
+
+func (failingCheckpointer) MarkProcessed(string) error     { return errors.New("store down") }
+func (failingCheckpointer) LastCheckpoint() (string, bool) { return "", false }
+
+func TestCheckpointed_ReportsErrors(t *testing.T) {
+	t.Parallel()
+
+	var failedID string
+	onSuccess := Checkpointed[string, string](failingCheckpointer{}, func(r string) string { return r }, nil,
+		func(ctx context.Context, id string, err error) {
+			failedID = id
+		})
+
+	onSuccess(context.Background(), rop.Success("a"))
+	if failedID != "a" {
+		t.Fatalf("expected the failing mark reported for a, got %q", failedID)
+	}
+}
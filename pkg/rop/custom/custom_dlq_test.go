@@ -0,0 +1,82 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRunWithDLQ(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	errOdd := errors.New("odd")
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		if v%2 == 1 {
+			return 0, errOdd
+		}
+		return v * 10, nil
+	}, nil)
+
+	out, dlq := RunWithDLQ(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5}), engine,
+		core.CancellationHandlers[int, int]{}, nil, 2)
+
+	var letters []DeadLetter[int, int]
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for l := range dlq {
+			letters = append(letters, l)
+		}
+	}()
+
+	succeeded, failed := 0, 0
+	for r := range out {
+		if r.IsSuccess() {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+	wg.Wait()
+
+	if succeeded != 2 || failed != 3 {
+		t.Fatalf("expected 2 successes and 3 failures on the output, got %d and %d", succeeded, failed)
+	}
+	if len(letters) != 3 {
+		t.Fatalf("expected 3 dead letters, got %d", len(letters))
+	}
+	for _, l := range letters {
+		if l.Input.Result()%2 != 1 || !errors.Is(l.Result.Err(), errOdd) {
+			t.Fatalf("expected odd inputs with their failure, got %v -> %v", l.Input.Result(), l.Result.Err())
+		}
+	}
+}
+
+func TestRunWithDLQ_SkipsCancels(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 1)
+	in <- rop.Cancel[int](ErrCancelled)
+	close(in)
+
+	out, dlq := RunWithDLQ(ctx, in, Map(func(ctx context.Context, v int) int { return v }, nil),
+		core.CancellationHandlers[int, int]{}, nil, 1)
+
+	for range out {
+	}
+	if _, ok := <-dlq; ok {
+		t.Fatal("expected no dead letter for a cancel")
+	}
+}
@@ -0,0 +1,133 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+func failOdd(ctx context.Context, r int) rop.Result[int] {
+	if r%2 != 0 {
+		return rop.Fail[int](errors.New("odd"))
+	}
+	return rop.Success(r)
+}
+
+func TestRun_EmitSuccessOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var calls int32
+	onSuccess := func(ctx context.Context, in rop.Result[int]) {
+		if !in.IsSuccess() {
+			t.Errorf("callback must not fire for failures in EmitSuccessOnly mode")
+		}
+		atomic.AddInt32(&calls, 1)
+	}
+
+	emitCtx := core.WithEmitOptions(ctx, core.EmitSuccessOnly)
+	for range Run(emitCtx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5, 6}),
+		Switch(failOdd, nil), core.CancellationHandlers[int, int]{}, onSuccess, 2) {
+	}
+
+	if calls != 3 {
+		t.Fatalf("expected 3 success callbacks, got %d", calls)
+	}
+}
+
+func TestRun_EmitAllIsDefault(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var calls int32
+	for range Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}),
+		Switch(failOdd, nil), core.CancellationHandlers[int, int]{},
+		func(ctx context.Context, in rop.Result[int]) { atomic.AddInt32(&calls, 1) }, 2) {
+	}
+
+	if calls != 4 {
+		t.Fatalf("expected a callback for every emission, got %d", calls)
+	}
+}
+
+func TestRun_OnEmittedPerOutcome(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var successes, failures int32
+	onEmitted := core.OnEmitted[int](
+		func(ctx context.Context, r int) { atomic.AddInt32(&successes, 1) },
+		func(ctx context.Context, err error) { atomic.AddInt32(&failures, 1) },
+		nil)
+
+	for range Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5}),
+		Switch(failOdd, nil), core.CancellationHandlers[int, int]{}, onEmitted, 3) {
+	}
+
+	if successes != 2 || failures != 3 {
+		t.Fatalf("expected 2 successes and 3 failures, got %d and %d", successes, failures)
+	}
+}
+
+func TestFilter_OnDrop(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var dropped atomic.Int32
+	engine := Filter(func(ctx context.Context, v int) bool { return v > 2 },
+		func(ctx context.Context, in rop.Result[int]) { dropped.Add(1) }, nil)
+
+	kept := core.FromChanMany(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine,
+		core.CancellationHandlers[int, int]{}, nil, 2))
+
+	if len(kept) != 2 || dropped.Load() != 2 {
+		t.Fatalf("expected 2 kept and 2 dropped, got %d and %d", len(kept), dropped.Load())
+	}
+}
+
+func TestBatch_FlushesPartialBatchOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int])
+	out := Batch(ctx, in, 10, time.Minute, mass.BatchingCancelHandlers[int]{
+		OnCancel: CancelRemainingResults[int, []int],
+	})
+
+	in <- rop.Success(1)
+	in <- rop.Success(2)
+	cancel()
+	close(in)
+
+	var batches, canceled int
+	for r := range out {
+		switch {
+		case r.IsSuccess():
+			batches++
+			if len(r.Result()) != 2 {
+				t.Fatalf("expected the partial batch of 2, got %v", r.Result())
+			}
+		case r.IsCancel():
+			canceled++
+		}
+	}
+
+	if batches != 1 || canceled != 0 {
+		t.Fatalf("expected only the partial batch, got %d batches and %d cancels", batches, canceled)
+	}
+}
@@ -0,0 +1,80 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestStart_RunsThrough(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	h := Start(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), failingFrom(3),
+		core.CancellationHandlers[int, int]{}, nil, 2)
+	for range h.Out() {
+	}
+
+	if err := h.Wait(); err != nil {
+		t.Fatalf("expected no error for a complete run, got %v", err)
+	}
+	stats := h.Stats()
+	if stats.Processed != 4 || stats.Succeeded != 2 || stats.Failed != 2 || stats.Canceled != 0 {
+		t.Fatalf("expected 2 successes and 2 failures, got %+v", stats)
+	}
+	if stats.Duration <= 0 || h.Stats().Duration != stats.Duration {
+		t.Fatalf("expected a fixed duration once done, got %v", stats.Duration)
+	}
+}
+
+func TestStart_Stop(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	errMaintenance := errors.New("maintenance")
+	sourceCtx, stopSource := context.WithCancel(ctx)
+	defer stopSource()
+	h := Start(ctx, endless(sourceCtx), Map(func(ctx context.Context, v int) int { return v }, nil),
+		DrainAsCancelled[int, int](), nil, 2)
+
+	for r := range h.Out() {
+		if r.IsSuccess() && r.Result() == 10 {
+			h.Stop(errMaintenance)
+			stopSource()
+		}
+	}
+
+	if err := h.Wait(); !errors.Is(err, errMaintenance) {
+		t.Fatalf("expected the stop cause, got %v", err)
+	}
+	if stats := h.Stats(); stats.Succeeded < 10 {
+		t.Fatalf("expected at least 10 successes before the stop, got %+v", stats)
+	}
+}
+
+func TestStart_WaitWithoutResults(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int])
+	close(in)
+	h := Start(ctx, in, Map(func(ctx context.Context, v int) int { return v }, nil),
+		core.CancellationHandlers[int, int]{}, nil, 1)
+
+	if err := h.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := <-h.Out(); ok {
+		t.Fatal("expected a closed output")
+	}
+}
@@ -0,0 +1,83 @@
+package custom
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRunSingleLookahead(t *testing.T) {
+	t.Parallel()
+
+	for _, lookahead := range []int{1, 3} {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+
+		var running, peak atomic.Int32
+		engine := Map(func(ctx context.Context, v int) int {
+			n := running.Add(1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(time.Duration(10-v) * time.Millisecond)
+			running.Add(-1)
+			return v
+		}, nil)
+
+		got := core.FromChanMany(ctx, RunSingleLookahead(ctx, core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}),
+			engine, core.CancellationHandlers[int, int]{}, nil, lookahead))
+		cancel()
+
+		if len(got) != 10 {
+			t.Fatalf("lookahead %d: expected 10 results, got %d", lookahead, len(got))
+		}
+		for i, r := range got {
+			if r.Result() != i {
+				t.Fatalf("lookahead %d: expected %d at position %d, got %d", lookahead, i, i, r.Result())
+			}
+		}
+		if p := int(peak.Load()); p > lookahead || (lookahead > 1 && p < 2) {
+			t.Fatalf("lookahead %d: expected up to %d items at once, peaked at %d", lookahead, lookahead, p)
+		}
+	}
+}
+
+func TestRunSingleLookahead_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int], 6)
+	for v := range 6 {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	// 0 runs until the cancel, so 1 and 2 wait behind it in the window
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		if v == 0 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return v, nil
+	}, nil)
+
+	out := RunSingleLookahead(ctx, in, engine, DrainAsCancelled[int, int](), nil, 3)
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	var got []rop.Result[int]
+	for r := range out {
+		got = append(got, r)
+	}
+	if len(got) != 6 {
+		t.Fatalf("expected every item accounted for as a cancel, got %d", len(got))
+	}
+	for _, r := range got {
+		if !r.IsCancel() {
+			t.Fatalf("expected only cancels, got %v", r)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestTurnoutOrdered_KeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Map(func(ctx context.Context, v int) int {
+		time.Sleep(time.Duration(10-v) * time.Millisecond)
+		return v
+	}, nil)
+
+	got := core.FromChanMany(ctx, TurnoutOrdered(ctx, core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}),
+		engine, 4))
+	if len(got) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(got))
+	}
+	for i, r := range got {
+		if r.Result() != i {
+			t.Fatalf("expected %d at position %d, got %d", i, i, r.Result())
+		}
+	}
+}
+
+func TestTurnoutOrdered_DrainOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int], 4)
+	for v := range 4 {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	// 0 never finishes before the cancel, the others finish right away
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		if v == 0 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return v, nil
+	}, nil)
+
+	out := TurnoutOrdered(ctx, in, engine, 4)
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	var got []rop.Result[int]
+	for r := range out {
+		got = append(got, r)
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("expected all 4 positions covered, got %d", len(got))
+	}
+	if !got[0].IsCancel() || !errors.Is(got[0].Err(), context.Canceled) {
+		t.Fatalf("expected position 0 canceled, got %v", got[0])
+	}
+	for i := 1; i < 4; i++ {
+		if !got[i].IsSuccess() || got[i].Result() != i {
+			t.Fatalf("expected the completed %d at its position, got %v", i, got[i])
+		}
+	}
+}
+
+func TestTurnoutOrdered_SkippedPositions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	evens := Filter(func(ctx context.Context, v int) bool { return v%2 == 0 }, nil, nil)
+	got := core.FromChanMany(ctx, TurnoutOrdered(ctx, core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4}), evens, 2))
+
+	if len(got) != 3 || got[0].Result() != 0 || got[1].Result() != 2 || got[2].Result() != 4 {
+		t.Fatalf("expected 0, 2, 4, got %v", got)
+	}
+}
+
+func TestTurnoutOrdered_DrainWithSlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	for range 20 {
+		// with the remaining input drained too, every input position is covered
+		ctx, cancel := context.WithCancel(core.WithProcessOptions(context.Background(), true))
+
+		in := make(chan rop.Result[int], 20)
+		for v := range 20 {
+			in <- rop.Success(v)
+		}
+		close(in)
+
+		out := TurnoutOrdered(ctx, in, Map(func(ctx context.Context, v int) int { return v }, nil), 3)
+
+		// the collector is blocked on its send while the cancel comes in
+		// and the lines stop
+		first := <-out
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+		time.Sleep(5 * time.Millisecond)
+
+		got := []rop.Result[int]{first}
+		for r := range out {
+			got = append(got, r)
+		}
+
+		if len(got) != 20 {
+			t.Fatalf("expected all 20 positions covered, got %d", len(got))
+		}
+		for i, r := range got {
+			if r.IsSuccess() && r.Result() != i {
+				t.Fatalf("expected %d at position %d, got %d", i, i, r.Result())
+			}
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func remainingInput() <-chan rop.Result[int] {
+	in := make(chan rop.Result[int], 2)
+	in <- rop.Success(1)
+	in <- rop.Cancel[int](context.DeadlineExceeded)
+	close(in)
+	return in
+}
+
+func TestDrainAsCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	handlers := DrainAsCancelled[int, string]()
+	out := make(chan rop.Result[string], 4)
+
+	handlers.OnCancel(ctx, remainingInput(), out)
+	handlers.OnCancelUnprocessed(ctx, rop.Success(3), out)
+	handlers.OnCancelProcessed(ctx, rop.Success(4), rop.Success("4"), out)
+	close(out)
+
+	var errs []error
+	for r := range out {
+		if !r.IsCancel() {
+			t.Fatalf("expected only cancels, got %v", r)
+		}
+		errs = append(errs, r.Err())
+	}
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 cancels, got %d", len(errs))
+	}
+	if !errors.Is(errs[0], ErrCancelled) || !errors.Is(errs[1], context.DeadlineExceeded) {
+		t.Fatalf("expected a canceled input to keep its error, got %v", errs[:2])
+	}
+}
+
+func TestEmitProcessed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	handlers := EmitProcessed[int, string]()
+	out := make(chan rop.Result[string], 1)
+
+	handlers.OnCancelProcessed(ctx, rop.Success(4), rop.Success("4"), out)
+	if r := <-out; !r.IsSuccess() || r.Result() != "4" {
+		t.Fatalf("expected the processed result emitted, got %v", r)
+	}
+	if handlers.OnCancel == nil || handlers.OnCancelUnprocessed == nil {
+		t.Fatal("expected remaining and unprocessed items to be canceled")
+	}
+}
+
+func TestDropRemaining(t *testing.T) {
+	t.Parallel()
+
+	handlers := DropRemaining[int, string]()
+	in := remainingInput()
+	out := make(chan rop.Result[string], 2)
+
+	handlers.OnCancel(context.Background(), in, out)
+	if len(out) != 0 {
+		t.Fatalf("expected nothing emitted, got %d", len(out))
+	}
+	if _, ok := <-in; ok {
+		t.Fatal("expected the remaining input drained")
+	}
+}
+
+func TestCancelStatsHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	handlers, stats := CancelStatsHandlers(DrainAsCancelled[int, string]())
+	out := make(chan rop.Result[string], 4)
+
+	handlers.OnCancel(ctx, remainingInput(), out)
+	handlers.OnCancelUnprocessed(ctx, rop.Success(3), out)
+	handlers.OnCancelProcessed(ctx, rop.Success(4), rop.Success("4"), out)
+
+	if n := len(out); n != 4 {
+		t.Fatalf("expected the wrapped strategy to emit 4 cancels, got %d", n)
+	}
+	expected := CancelTotals{Unprocessed: 1, Processed: 1, Drained: 2}
+	if got := stats.Totals(); got != expected {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCancelStatsHandlers_InPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int], 10)
+	for v := range 10 {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	started := make(chan struct{})
+	engine := func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int])
+		go func() {
+			defer close(out)
+			close(started)
+			<-ctx.Done()
+			// stay running past the cancel, so the line sees the item unprocessed
+			time.Sleep(10 * time.Millisecond)
+		}()
+		return out
+	}
+
+	handlers, stats := CancelStatsHandlers(DropRemaining[int, int]())
+	out := Run(ctx, in, engine, handlers, nil, 1)
+
+	<-started
+	cancel()
+	for range out {
+	}
+
+	expected := CancelTotals{Unprocessed: 1, Drained: 9}
+	if got := stats.Totals(); got != expected {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestCancelRemainingResult_Cause(t *testing.T) {
+	t.Parallel()
+
+	errShutdown := errors.New("shutdown")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(errShutdown)
+
+	out := make(chan rop.Result[int], 1)
+	CancelRemainingResult[int, int](ctx, rop.Success(1), out)
+
+	r := <-out
+	if !r.IsCancel() || !errors.Is(r.Err(), errShutdown) || !errors.Is(r.Err(), ErrCancelled) {
+		t.Fatalf("expected a cancel carrying both ErrCancelled and the cause, got %v", r.Err())
+	}
+}
+
+func TestCancelStatsHandlers_StopsWithHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx, group := core.WithGroup(context.Background())
+	in := make(chan rop.Result[int], 1)
+	in <- rop.Success(1)
+
+	// with ProcessRemaining off the wrapped handler returns at once
+	handlers, stats := CancelStatsHandlers(core.CancellationHandlers[int, int]{
+		OnCancel: CancelRemainingResults[int, int],
+	})
+	handlers.OnCancel(core.WithProcessOptions(ctx, false), in, make(chan rop.Result[int], 1))
+
+	// in stays open: the forwarding must not outlive the handler
+	if err := group.WaitTimeout(100 * time.Millisecond); err != nil {
+		t.Fatalf("expected no forwarding left running, got %v", err)
+	}
+	if got := stats.Totals().Drained; got != int64(1-len(in)) {
+		t.Fatalf("expected every item taken from the input counted, got %d with %d left", got, len(in))
+	}
+}
@@ -0,0 +1,82 @@
+package custom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func filled(values ...int) chan rop.Result[int] {
+	ch := make(chan rop.Result[int], len(values))
+	for _, v := range values {
+		ch <- rop.Success(v)
+	}
+	close(ch)
+	return ch
+}
+
+func TestRunPrioritized_HighFirst(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	out := RunPrioritized(ctx, filled(1, 2), filled(10, 20), filled(100), Map(func(ctx context.Context, v int) int {
+		return v
+	}, nil), core.CancellationHandlers[int, int]{}, nil, 1)
+
+	got := core.FromChanMany(ctx, out)
+	expected := []int{1, 2, 10, 20, 100}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i].Result() != expected[i] {
+			t.Fatalf("expected %v, got %v at %d", expected[i], got[i].Result(), i)
+		}
+	}
+}
+
+func TestRunPrioritized_NoStarvation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	high := make([]int, 40)
+	out := RunPrioritized(ctx, filled(high...), filled(10), filled(100), Map(func(ctx context.Context, v int) int {
+		return v
+	}, nil), core.CancellationHandlers[int, int]{}, nil, 1)
+
+	got := core.FromChanMany(ctx, out)
+	if len(got) != 42 {
+		t.Fatalf("expected 42 results, got %d", len(got))
+	}
+	for i, r := range got {
+		if r.Result() == 100 {
+			if i >= lowEvery {
+				t.Fatalf("expected the low item within %d picks, got it at %d", lowEvery, i)
+			}
+			return
+		}
+	}
+	t.Fatal("expected the low item emitted")
+}
+
+func TestRunPrioritized_NilInputs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	out := RunPrioritized(ctx, nil, filled(1, 2), nil, Map(func(ctx context.Context, v int) int {
+		return v
+	}, nil), core.CancellationHandlers[int, int]{}, nil, 2)
+
+	if got := core.FromChanMany(ctx, out); len(got) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(got))
+	}
+}
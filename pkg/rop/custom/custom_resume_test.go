@@ -0,0 +1,91 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// lastOnly is a Checkpointer without ProcessedChecker, as one persisting only a position
+type lastOnly struct {
+	last int
+}
+
+func (c *lastOnly) MarkProcessed(id int) error  { c.last = id; return nil }
+func (c *lastOnly) LastCheckpoint() (int, bool) { return c.last, c.last != 0 }
+
+func TestResume_FiltersProcessed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cp := NewMemoryCheckpointer[int]()
+	_ = cp.MarkProcessed(2)
+	_ = cp.MarkProcessed(4)
+
+	got := core.FromChanMany(ctx, Resume(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5}), cp,
+		func(r int) int { return r }))
+
+	expected := []int{1, 3, 5}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %d results", expected, len(got))
+	}
+	for i := range expected {
+		if got[i].Result() != expected[i] {
+			t.Fatalf("expected %d at %d, got %d", expected[i], i, got[i].Result())
+		}
+	}
+}
+
+func TestResume_FastForwards(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	in <- rop.Success(1)
+	in <- rop.Fail[int](ErrCancelled)
+	in <- rop.Success(2)
+	in <- rop.Success(3)
+	in <- rop.Success(4)
+	close(in)
+
+	got := core.FromChanMany(ctx, Resume[int, int](ctx, in, &lastOnly{last: 2}, func(r int) int { return r }))
+
+	if len(got) != 3 || got[0].IsSuccess() || got[1].Result() != 3 || got[2].Result() != 4 {
+		t.Fatalf("expected the failure, 3 and 4, got %v", got)
+	}
+}
+
+func TestResume_NoCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got := core.FromChanMany(ctx, Resume[int, int](ctx, core.ToChanManyResults(ctx, []int{1, 2}), &lastOnly{},
+		func(r int) int { return r }))
+	if len(got) != 2 {
+		t.Fatalf("expected everything passed on, got %d", len(got))
+	}
+}
+
+func TestResume_CheckpointNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	got := core.FromChanMany(ctx, Resume[int, int](ctx, core.ToChanManyResults(ctx, []int{1, 2, 3}),
+		&lastOnly{last: 9}, func(r int) int { return r }))
+
+	if len(got) != 1 || !errors.Is(got[0].Err(), ErrCheckpointNotFound) {
+		t.Fatalf("expected only ErrCheckpointNotFound, got %v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRunWithRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errFlaky := errors.New("flaky")
+	var mu sync.Mutex
+	tries := map[int]int{}
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		tries[v]++
+		// 1 recovers on the third try, 2 never does
+		if v == 2 || (v == 1 && tries[v] < 3) {
+			return 0, errFlaky
+		}
+		return v, nil
+	}, nil)
+
+	out := RunWithRetry(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine,
+		3, core.ConstantBackoff(20*time.Millisecond), core.CancellationHandlers[int, int]{}, nil, 1)
+
+	var order []int
+	var retryErr *core.RetryError
+	for r := range out {
+		if r.IsSuccess() {
+			order = append(order, r.Result())
+		} else if !errors.As(r.Err(), &retryErr) {
+			t.Fatalf("expected a *core.RetryError, got %v", r.Err())
+		}
+	}
+
+	if len(order) != 3 || order[len(order)-1] != 1 {
+		t.Fatalf("expected 3, 4 ahead of the requeued 1, got %v", order)
+	}
+	if retryErr == nil || retryErr.Attempts != 3 || !errors.Is(retryErr, errFlaky) {
+		t.Fatalf("expected 2 to fail after 3 attempts, got %v", retryErr)
+	}
+	if tries[2] != 3 {
+		t.Fatalf("expected 3 tries of 2, got %d", tries[2])
+	}
+}
+
+func TestRunWithRetry_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int], 1)
+	in <- rop.Success(1)
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		return 0, errors.New("always")
+	}, nil)
+
+	out := RunWithRetry(ctx, in, engine, 100, core.ConstantBackoff(time.Millisecond),
+		core.CancellationHandlers[int, int]{}, nil, 2)
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatal("expected no result for an item dropped while waiting for a retry")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the output closed on cancel")
+	}
+}
+
+func TestRunWithRetry_LocomotiveHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	received := map[int]int{}
+	ctx = core.WithLocomotiveHooks(ctx, "", core.LocomotiveHooks[int, int]{
+		OnReceive: func(ctx context.Context, in rop.Result[int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[in.Result()]++
+		},
+	})
+
+	tries := 0
+	engine := Try(func(ctx context.Context, v int) (int, error) {
+		if tries++; tries < 2 {
+			return 0, errors.New("flaky")
+		}
+		return v, nil
+	}, nil)
+
+	results := core.FromChanMany(ctx, RunWithRetry(ctx, core.ToChanManyResults(ctx, []int{7}), engine,
+		3, nil, core.CancellationHandlers[int, int]{}, nil, 1))
+
+	if len(results) != 1 || !results[0].IsSuccess() {
+		t.Fatalf("expected a single success, got %v", results)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if received[7] != 2 {
+		t.Fatalf("expected the hooks to see the input on both attempts, got %v", received)
+	}
+}
@@ -0,0 +1,67 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+func TestFinallyWithSnapshot_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	errAbort := errors.New("operator abort")
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	in := make(chan rop.Result[int], 5)
+	for v := range 5 {
+		in <- rop.Success(v)
+	}
+
+	handlers := mass.DefaultFinallyHandlers(func(ctx context.Context, r int) int {
+		time.Sleep(10 * time.Millisecond)
+		return r
+	})
+	out, snapshots := FinallyWithSnapshot(ctx, in, handlers, mass.FinallyCancelHandlers[int, int]{}, nil)
+
+	<-out
+	<-out
+	cancel(errAbort)
+	for range out {
+	}
+
+	snapshot, ok := <-snapshots
+	if !ok {
+		t.Fatal("expected a snapshot")
+	}
+	if !errors.Is(snapshot.Reason, errAbort) {
+		t.Fatalf("expected the cancel cause as reason, got %v", snapshot.Reason)
+	}
+	if len(snapshot.Emitted) < 2 || snapshot.Emitted[0] != 0 || snapshot.Emitted[1] != 1 {
+		t.Fatalf("expected 0 and 1 emitted first, got %v", snapshot.Emitted)
+	}
+	if n := len(snapshot.Emitted) + len(snapshot.Unsent) + len(snapshot.Pending); n != 5 {
+		t.Fatalf("expected all 5 inputs accounted for, got %+v", snapshot)
+	}
+}
+
+func TestFinallyWithSnapshot_Completed(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	out, snapshots := FinallyWithSnapshot(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3}),
+		mass.IdentityFinallyHandlers[int](), mass.FinallyCancelHandlers[int, int]{}, nil)
+	for range out {
+	}
+
+	snapshot := <-snapshots
+	if snapshot.Reason != nil || len(snapshot.Emitted) != 3 || len(snapshot.Pending) != 0 {
+		t.Fatalf("expected a complete run, got %+v", snapshot)
+	}
+}
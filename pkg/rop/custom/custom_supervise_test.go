@@ -0,0 +1,148 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestSupervise_RestartsPanickingLines(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sup := Supervise(ctx, core.RestartPolicy{MaxRestarts: 5}, "parse", "store")
+
+	var panics atomic.Int32
+	onSuccess := func(ctx context.Context, in rop.Result[int]) {
+		// the line itself dies on the first two emits
+		if in.Result() < 2 && panics.Add(1) <= 2 {
+			panic("store hiccup")
+		}
+	}
+
+	parsed := Run(sup.Context("parse"), core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4}),
+		Map(func(ctx context.Context, v int) int { return v }, nil), core.CancellationHandlers[int, int]{}, nil, 1)
+	stored := Run(sup.Context("store"), parsed, Map(func(ctx context.Context, v int) int { return v }, nil),
+		core.CancellationHandlers[int, int]{}, onSuccess, 1)
+
+	if n := len(core.FromChanMany(ctx, stored)); n != 5 {
+		t.Fatalf("expected every item emitted, got %d", n)
+	}
+
+	health := sup.Health()
+	if !health.Healthy || len(health.Stages) != 2 {
+		t.Fatalf("expected a healthy composition of 2 stages, got %+v", health)
+	}
+	if h := health.Stages[1]; h.Name != "store" || h.Restarts != 2 || h.LastPanic == nil {
+		t.Fatalf("expected 2 restarts of store, got %+v", h)
+	}
+	if health.Stages[0].Restarts != 0 {
+		t.Fatalf("expected no restart of parse, got %d", health.Stages[0].Restarts)
+	}
+}
+
+func TestSupervise_GiveUpStopsAllStages(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sup := Supervise(ctx, core.RestartPolicy{MaxRestarts: 1})
+
+	in := make(chan rop.Result[int])
+	go func() {
+		for v := 0; ; v++ {
+			select {
+			case in <- rop.Success(v):
+			case <-sup.Done():
+				close(in)
+				return
+			}
+		}
+	}()
+
+	panicking := func(ctx context.Context, in rop.Result[int]) {
+		panic("broken")
+	}
+	out := Run(sup.Context("store"), in, Map(func(ctx context.Context, v int) int { return v }, nil),
+		core.CancellationHandlers[int, int]{}, panicking, 1)
+
+	for range out {
+	}
+
+	health := sup.Health()
+	if health.Healthy || !health.Stages[0].GaveUp {
+		t.Fatalf("expected the store stage given up, got %+v", health)
+	}
+	if stage, ok := core.FailedStage(health.Err); !ok || stage != "store" {
+		t.Fatalf("expected the stop cause to name the store stage, got %v", health.Err)
+	}
+	var p *rop.PanicError
+	if !errors.As(health.Err, &p) {
+		t.Fatalf("expected the stop cause to carry the panic, got %v", health.Err)
+	}
+}
+
+func TestSupervise_EnginePanicCountsAsRestart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sup := Supervise(ctx, core.RestartPolicy{MaxRestarts: 2})
+
+	engine := Map(func(ctx context.Context, v int) int {
+		if v == 2 {
+			panic("boom")
+		}
+		return v
+	}, nil)
+	out := Run(sup.Context("parse"), core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4}), engine,
+		core.CancellationHandlers[int, int]{}, nil, 1)
+
+	if n := len(core.FromChanMany(ctx, out)); n != 4 {
+		t.Fatalf("expected every other item emitted, got %d", n)
+	}
+
+	health := sup.Health()
+	if !health.Healthy {
+		t.Fatalf("expected a healthy composition, got %+v", health)
+	}
+	if h := health.Stages[0]; h.Restarts != 1 || h.LastPanic == nil || h.LastPanic.Value != "boom" {
+		t.Fatalf("expected the engine panic counted as a restart, got %+v", h)
+	}
+}
+
+func TestSupervise_EnginePanicsGiveUp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sup := Supervise(ctx, core.RestartPolicy{MaxRestarts: 1})
+
+	engine := Map(func(ctx context.Context, v int) int {
+		panic("boom")
+	}, nil)
+	out := Run(sup.Context("parse"), core.ToChanManyResults(ctx, []int{0, 1, 2, 3, 4}), engine,
+		core.CancellationHandlers[int, int]{}, nil, 1)
+
+	for range out {
+	}
+	<-sup.Done()
+
+	health := sup.Health()
+	if health.Healthy || !health.Stages[0].GaveUp {
+		t.Fatalf("expected the parse stage given up, got %+v", health)
+	}
+	if stage, ok := core.FailedStage(health.Err); !ok || stage != "parse" {
+		t.Fatalf("expected the stop cause to name the parse stage, got %v", health.Err)
+	}
+}
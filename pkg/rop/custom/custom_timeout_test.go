@@ -0,0 +1,56 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestWithTimeout_CancelsOnlyExpiredItem(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []core.ExecutionMode{core.ExecuteAsync, core.ExecuteFused} {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+
+		var canceled atomic.Int32
+		var canceledInput atomic.Int64
+		onCancel := func(ctx context.Context, in rop.Result[int]) {
+			canceled.Add(1)
+			canceledInput.Store(int64(in.Result()))
+		}
+		engine := WithTimeout(Try(func(ctx context.Context, d int) (int, error) {
+			select {
+			case <-time.After(time.Duration(d) * time.Millisecond):
+				return d, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}, onCancel), 30*time.Millisecond)
+
+		results := core.FromChanMany(ctx, Run(core.WithExecutionOptions(ctx, mode),
+			core.ToChanManyResults(ctx, []int{1, 500, 2}), engine, core.CancellationHandlers[int, int]{}, nil, 3))
+		cancel()
+
+		succeeded, cancels := 0, 0
+		for _, r := range results {
+			switch {
+			case r.IsSuccess():
+				succeeded++
+			case r.IsCancel() && errors.Is(r.Err(), context.DeadlineExceeded):
+				cancels++
+			}
+		}
+		if succeeded != 2 || cancels != 1 {
+			t.Fatalf("mode %d: expected 2 successes and 1 expired item, got %d and %d", mode, succeeded, cancels)
+		}
+		if canceled.Load() != 1 || canceledInput.Load() != 500 {
+			t.Fatalf("mode %d: expected onCancel once for the expired 500, got %d for %d",
+				mode, canceled.Load(), canceledInput.Load())
+		}
+	}
+}
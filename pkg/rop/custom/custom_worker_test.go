@@ -0,0 +1,79 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type lineBuffer struct {
+	id    int
+	items []int
+}
+
+func TestRun_WorkerHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	started := map[int]bool{}
+	stopped := map[int]int{}
+
+	stageCtx := core.WithWorkerHooks(ctx, core.WorkerHooks[*lineBuffer]{
+		OnWorkerStart: func(ctx context.Context, workerID int) *lineBuffer {
+			mu.Lock()
+			defer mu.Unlock()
+			started[workerID] = true
+			return &lineBuffer{id: workerID}
+		},
+		OnWorkerStop: func(ctx context.Context, workerID int, state *lineBuffer) {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped[workerID] = len(state.items)
+		},
+	})
+
+	engine := func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int], 1)
+		buf, ok := core.WorkerState[*lineBuffer](ctx)
+		id, _ := core.WorkerID(ctx)
+		if !ok || buf.id != id {
+			out <- rop.Fail[int](ErrCancelled)
+		} else {
+			// no lock: the buffer belongs to this line only
+			buf.items = append(buf.items, input.Result())
+			out <- input
+		}
+		close(out)
+		return out
+	}
+
+	results := core.FromChanMany(ctx, Run(stageCtx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5, 6}),
+		engine, core.CancellationHandlers[int, int]{}, nil, 3))
+
+	for _, r := range results {
+		if !r.IsSuccess() {
+			t.Fatal("expected every engine call to see the state of its own line")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(started) != 3 || len(stopped) != 3 {
+		t.Fatalf("expected 3 lines started and stopped before the output closed, got %d and %d",
+			len(started), len(stopped))
+	}
+	total := 0
+	for _, n := range stopped {
+		total += n
+	}
+	if total != 6 {
+		t.Fatalf("expected the line buffers to hold all 6 items, got %d", total)
+	}
+}
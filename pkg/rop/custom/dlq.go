@@ -0,0 +1,73 @@
+package custom
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+// DeadLetter is a failed result together with the input that produced it.
+type DeadLetter[In, Out any] struct {
+	Input  rop.Result[In]
+	Result rop.Result[Out]
+}
+
+// RunWithDLQ is Turnout that also copies every failure (not cancels) to the
+// returned dead letter channel with its original input, so rejects can be
+// persisted for later reprocessing. Both channels must be read; the dead
+// letter channel is closed after the output.
+func RunWithDLQ[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	handlers core.CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), lines int) (<-chan rop.Result[Out],
+	<-chan DeadLetter[In, Out]) {
+
+	out := make(chan rop.Result[Out], core.GetBufferOptions(ctx).ChannelSize())
+	dlq := make(chan DeadLetter[In, Out], core.GetBufferSize(ctx, 0))
+	wg := &sync.WaitGroup{}
+	calls := &sync.WaitGroup{}
+
+	core.StartLines(ctx, lines, inputCh, out, deadLettering(engine, dlq, calls), handlers, onSuccess, wg)
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+		// a canceled line may leave its engine still running
+		calls.Wait()
+		close(dlq)
+	})
+
+	return mass.Buffered(ctx, out), dlq
+}
+
+func deadLettering[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	dlq chan<- DeadLetter[In, Out], calls *sync.WaitGroup) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+
+		calls.Add(1)
+		core.Go(ctx, func() {
+			defer calls.Done()
+			defer close(out)
+
+			pr, ok := <-engine(ctx, input)
+			if !ok {
+				return
+			}
+			if !pr.IsSuccess() && !pr.IsCancel() {
+				select {
+				case dlq <- DeadLetter[In, Out]{Input: input, Result: pr}:
+				case <-ctx.Done():
+				}
+			}
+			out <- pr
+		})
+
+		return out
+	}
+}
@@ -5,6 +5,28 @@
 //
 // Key constructs:
 // - Run/RunSingle: orchestrate engines with handlers and success callbacks
+// - RunSingleLookahead: RunSingle running up to k items ahead, still emitting in input order
+// - Start: Run returning a Handle with Out, Wait, Stop and Stats
+// - RunWithDLQ: Turnout that also reports failures with their input on a dead letter channel
+// - RunWithRetry: Turnout that requeues failed items with a backoff, emitting only terminal failures
+// - RunPrioritized: Run over high/normal/low inputs, favoring higher priorities without starving lower ones
+// - Checkpointed: onSuccess callback recording emitted IDs in a Checkpointer for restarts
+// - Resume: skip the input a Checkpointer has already seen when restarting a job
+// - Worker hooks (core.WithWorkerHooks): per-line init/teardown with state read via core.WorkerState
+// - RunAutoscaled: Run with a line count scaled between bounds on backlog and latency
+// - TurnoutOrdered: Turnout keeping input order, covering every position with a result or cancel on cancel
+// - Supervise: own the contexts of a multi-stage composition, restart panicking lines, report Health
+// - AbortAfter: cancel a pipeline with a *BudgetError once its failures exceed an ErrorBudget
+// - FinallyWithSnapshot: Finally that also reports emitted, unsent and pending items and the cancel reason
 // - Validate, Switch, Map, DoubleMap, Try: channel-lifted operations
+//   (WithTimeout gives each item a deadline, handing expired ones to onCancel)
+// - Filter: drop non-matching items, reporting them to onDrop
+// - Batch: batch successful results, flushing the partial batch on cancel (by
+//   default, via mass.FlushRemainingBatch) and the remaining input via CancelRemainingResults
+// - Run/Turnout/Finally: take core.Option values (core.WithWorkers/WithProcessRemaining/
+//   WithBuffer/WithName) in place of the context-value options
+// - NewHandlers/NewFinally: builders for cancellation and Finally handler sets, reporting missing handlers
+// - DrainAsCancelled/DropRemaining/EmitProcessed: ready-made cancellation handler presets
+//   (CancelStatsHandlers counts what any of them left undone)
 // - CancelRemaining* utilities: define how remaining items are canceled
 package custom
\ No newline at end of file
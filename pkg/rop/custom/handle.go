@@ -0,0 +1,108 @@
+package custom
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Stats counts the results a Handle has emitted so far.
+type Stats struct {
+	Processed int64
+	Succeeded int64
+	Failed    int64
+	Canceled  int64
+	// Duration is the time since Start, up to the close of the output once done
+	Duration time.Duration
+}
+
+// Handle is a running Run, managed as a long-lived component.
+type Handle[T any] struct {
+	out    chan rop.Result[T]
+	cancel context.CancelCauseFunc
+	done   chan struct{}
+	err    error
+
+	started   time.Time
+	finished  atomic.Int64
+	succeeded atomic.Int64
+	failed    atomic.Int64
+	canceled  atomic.Int64
+}
+
+// Start is Run returning a Handle instead of the bare output channel. The
+// output has to be read for the run to make progress.
+func Start[T any](ctx context.Context, inputCh <-chan rop.Result[T],
+	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
+	handlers core.CancellationHandlers[T, T],
+	onSuccess func(ctx context.Context, in rop.Result[T]), lines int) *Handle[T] {
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	h := &Handle[T]{
+		out:     make(chan rop.Result[T]),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		started: time.Now(),
+	}
+
+	out := Run(runCtx, inputCh, engine, handlers, onSuccess, lines)
+
+	core.Go(runCtx, func() {
+		defer close(h.done)
+		defer close(h.out)
+
+		for r := range out {
+			switch {
+			case r.IsSuccess():
+				h.succeeded.Add(1)
+			case r.IsCancel():
+				h.canceled.Add(1)
+			default:
+				h.failed.Add(1)
+			}
+			h.out <- r
+		}
+		h.finished.Store(int64(time.Since(h.started)))
+		if runCtx.Err() != nil {
+			h.err = context.Cause(runCtx)
+		}
+		cancel(nil)
+	})
+
+	return h
+}
+
+func (h *Handle[T]) Out() <-chan rop.Result[T] {
+	return h.out
+}
+
+// Wait blocks until the output is closed. It returns the cause the run was
+// stopped or canceled with, nil when it ran through its input.
+func (h *Handle[T]) Wait() error {
+	<-h.done
+	return h.err
+}
+
+// Stop cancels the run with cause; the cancel handlers still emit on Out.
+// Only the first cause counts.
+func (h *Handle[T]) Stop(cause error) {
+	h.cancel(cause)
+}
+
+func (h *Handle[T]) Stats() Stats {
+	succeeded, failed, canceled := h.succeeded.Load(), h.failed.Load(), h.canceled.Load()
+	duration := time.Duration(h.finished.Load())
+	if duration == 0 {
+		duration = time.Since(h.started)
+	}
+	return Stats{
+		Processed: succeeded + failed + canceled,
+		Succeeded: succeeded,
+		Failed:    failed,
+		Canceled:  canceled,
+		Duration:  duration,
+	}
+}
@@ -0,0 +1,118 @@
+package custom
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+type aheadSlot[T any] struct {
+	in  rop.Result[T]
+	res <-chan rop.Result[T]
+}
+
+// RunSingleLookahead is RunSingle that starts the engine on up to lookahead
+// items ahead while still emitting strictly in input order, so an ordered
+// stage gets parallel throughput. On cancel the item at the head and those
+// queued behind it go to the handlers in input order, then OnCancel gets the
+// remaining input.
+func RunSingleLookahead[T any](ctx context.Context, inputCh <-chan rop.Result[T],
+	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
+	handlers core.CancellationHandlers[T, T],
+	onSuccess func(ctx context.Context, in rop.Result[T]), lookahead int) <-chan rop.Result[T] {
+
+	if lookahead < 1 {
+		lookahead = 1
+	}
+	successOnly := core.GetEmitMode(ctx, core.EmitAll) == core.EmitSuccessOnly
+
+	out := make(chan rop.Result[T], core.GetBufferOptions(ctx).ChannelSize())
+	slots := make(chan aheadSlot[T], lookahead)
+	// a token per item between the start of its engine and its emit
+	window := make(chan struct{}, lookahead)
+
+	core.Go(ctx, func() {
+		defer close(slots)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case window <- struct{}{}:
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case in, ok := <-inputCh:
+				if !ok {
+					return
+				}
+				// never blocks: slots holds as many items as the window
+				slots <- aheadSlot[T]{in: in, res: engine(ctx, in)}
+			}
+		}
+	})
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		for slot := range slots {
+			select {
+			case <-ctx.Done():
+				cancelAhead(ctx, inputCh, slots, slot, handlers, out)
+				return
+			case pr, ok := <-slot.res:
+				if !ok {
+					if ctx.Err() != nil {
+						cancelAhead(ctx, inputCh, slots, slot, handlers, out)
+						return
+					}
+					<-window
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					if handlers.OnCancelProcessed != nil {
+						handlers.OnCancelProcessed(ctx, slot.in, pr, out)
+					}
+					cancelAhead(ctx, inputCh, slots, aheadSlot[T]{}, handlers, out)
+					return
+				case out <- pr:
+					<-window
+					if onSuccess != nil && (!successOnly || pr.IsSuccess()) {
+						onSuccess(ctx, pr)
+					}
+				}
+			}
+		}
+	})
+
+	return mass.Buffered(ctx, out)
+}
+
+// cancelAhead hands head (unless empty) and every queued item to
+// OnCancelUnprocessed in order, then the remaining input to OnCancel.
+func cancelAhead[T any](ctx context.Context, inputCh <-chan rop.Result[T], slots <-chan aheadSlot[T],
+	head aheadSlot[T], handlers core.CancellationHandlers[T, T], out chan<- rop.Result[T]) {
+
+	unprocessed := func(in rop.Result[T]) {
+		if handlers.OnCancelUnprocessed != nil {
+			handlers.OnCancelUnprocessed(ctx, in, out)
+		}
+	}
+
+	if head.res != nil {
+		unprocessed(head.in)
+	}
+	// slots is closed by the reader once it has seen the cancel
+	for slot := range slots {
+		unprocessed(slot.in)
+	}
+	if handlers.OnCancel != nil {
+		handlers.OnCancel(ctx, inputCh, out)
+	}
+}
@@ -0,0 +1,139 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type sequenced[T any] struct {
+	seq int64
+	r   rop.Result[T]
+	// skipped marks a position the engine emitted nothing for
+	skipped bool
+}
+
+// TurnoutOrdered is Turnout emitting results in input order: every input is
+// numbered and its result held back until those of all earlier inputs are out.
+// On cancel the output still covers every position in order: results ready at
+// that moment are emitted, the other positions (and, when processing of the
+// remaining values is enabled, the remaining input) become cancels, so
+// downstream can tell exactly which positions were completed.
+// A slow item holds back every result behind it.
+func TurnoutOrdered[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	lines int) <-chan rop.Result[Out] {
+
+	out := make(chan rop.Result[Out], core.GetBufferSize(ctx, 0))
+	work := make(chan sequenced[In])
+	results := make(chan sequenced[Out])
+	var admitted atomic.Int64
+
+	sequencerDone := make(chan struct{})
+	core.Go(ctx, func() {
+		defer close(sequencerDone)
+		defer close(work)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in, ok := <-inputCh:
+				if !ok {
+					return
+				}
+				seq := admitted.Add(1) - 1
+				select {
+				case work <- sequenced[In]{seq: seq, r: in}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	wg := &sync.WaitGroup{}
+	for range lines {
+		wg.Add(1)
+		core.Go(ctx, func() {
+			defer wg.Done()
+			for item := range work {
+				pr, ok := <-engine(ctx, item.r)
+				if !ok && ctx.Err() != nil {
+					// the position is left to the drain
+					return
+				}
+				select {
+				case results <- sequenced[Out]{seq: item.seq, r: pr, skipped: !ok}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(results)
+	})
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		ready := map[int64]sequenced[Out]{}
+		var next int64
+		for {
+			select {
+			case <-ctx.Done():
+				drainOrdered(ctx, inputCh, ready, next, sequencerDone, &admitted, out)
+				return
+			case sr, ok := <-results:
+				if !ok {
+					// the lines also stop on cancel, leaving their positions to the drain
+					if ctx.Err() != nil {
+						drainOrdered(ctx, inputCh, ready, next, sequencerDone, &admitted, out)
+					}
+					return
+				}
+				ready[sr.seq] = sr
+				for r, found := ready[next]; found; r, found = ready[next] {
+					if !r.skipped {
+						select {
+						case out <- r.r:
+						case <-ctx.Done():
+							// r is still ready at next, so the drain emits it first
+							drainOrdered(ctx, inputCh, ready, next, sequencerDone, &admitted, out)
+							return
+						}
+					}
+					delete(ready, next)
+					next++
+				}
+			}
+		}
+	})
+
+	return out
+}
+
+// drainOrdered emits, from position next on, the ready results and a cancel
+// for every other admitted position, then the remaining input as cancels.
+func drainOrdered[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	ready map[int64]sequenced[Out], next int64, sequencerDone <-chan struct{},
+	admitted *atomic.Int64, out chan<- rop.Result[Out]) {
+
+	<-sequencerDone
+	for seq := next; seq < admitted.Load(); seq++ {
+		if r, found := ready[seq]; found {
+			if !r.skipped {
+				out <- r.r
+			}
+		} else {
+			out <- rop.Cancel[Out](cancelCause(ctx))
+		}
+	}
+	CancelRemainingResults[In, Out](ctx, inputCh, out)
+}
@@ -0,0 +1,120 @@
+package custom
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+// a normal item is favored at least once every normalEvery picks and a low
+// one every lowEvery picks, so a busy higher priority cannot starve the rest
+const (
+	normalEvery = 4
+	lowEvery    = 16
+)
+
+// RunPrioritized is Run over three inputs, taking waiting items from highCh
+// before normalCh before lowCh. Lower priorities still get a share of the
+// picks while higher ones are busy. Any of the channels may be nil; the
+// output is closed once all of them are. OnCancel sees the merged input.
+func RunPrioritized[T any](ctx context.Context, highCh, normalCh, lowCh <-chan rop.Result[T],
+	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
+	handlers core.CancellationHandlers[T, T],
+	onSuccess func(ctx context.Context, in rop.Result[T]), lines int) <-chan rop.Result[T] {
+
+	out := make(chan rop.Result[T], core.GetBufferOptions(ctx).ChannelSize())
+	wg := &sync.WaitGroup{}
+
+	core.StartLines(ctx, lines, prioritize(ctx, highCh, normalCh, lowCh), out, engine, handlers, onSuccess, wg)
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+	})
+
+	return mass.Buffered(ctx, out)
+}
+
+func prioritize[T any](ctx context.Context, highCh, normalCh, lowCh <-chan rop.Result[T]) <-chan rop.Result[T] {
+	merged := make(chan rop.Result[T])
+
+	core.Go(ctx, func() {
+		defer close(merged)
+
+		inputs := []<-chan rop.Result[T]{highCh, normalCh, lowCh}
+		for picks := 1; ; picks++ {
+			first := 0
+			switch {
+			case picks%lowEvery == 0:
+				first = 2
+			case picks%normalEvery == 0:
+				first = 1
+			}
+
+			r, ok := pickWaiting(inputs, first)
+			if !ok {
+				if r, ok = pickAny(ctx, inputs); !ok {
+					return
+				}
+			}
+
+			select {
+			case merged <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return merged
+}
+
+// pickWaiting takes an item already waiting in inputs, probing them in priority
+// order starting at first. Closed inputs are set to nil.
+func pickWaiting[T any](inputs []<-chan rop.Result[T], first int) (rop.Result[T], bool) {
+	for i := range inputs {
+		idx := (first + i) % len(inputs)
+		if inputs[idx] == nil {
+			continue
+		}
+		select {
+		case r, ok := <-inputs[idx]:
+			if ok {
+				return r, true
+			}
+			inputs[idx] = nil
+		default:
+		}
+	}
+	return rop.Result[T]{}, false
+}
+
+// pickAny blocks for the next item of any input; it reports false once all of
+// them are closed or ctx is done.
+func pickAny[T any](ctx context.Context, inputs []<-chan rop.Result[T]) (rop.Result[T], bool) {
+	for inputs[0] != nil || inputs[1] != nil || inputs[2] != nil {
+		select {
+		case <-ctx.Done():
+			return rop.Result[T]{}, false
+		case r, ok := <-inputs[0]:
+			if ok {
+				return r, true
+			}
+			inputs[0] = nil
+		case r, ok := <-inputs[1]:
+			if ok {
+				return r, true
+			}
+			inputs[1] = nil
+		case r, ok := <-inputs[2]:
+			if ok {
+				return r, true
+			}
+			inputs[2] = nil
+		}
+	}
+	return rop.Result[T]{}, false
+}
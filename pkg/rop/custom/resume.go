@@ -0,0 +1,75 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// ErrCheckpointNotFound fails a fast-forwarding Resume whose source ended
+// without the checkpointed item, after it skipped all of it.
+var ErrCheckpointNotFound = errors.New("resume: checkpoint not found in source")
+
+// ProcessedChecker is implemented by checkpointers that can tell whether a
+// given ID was processed (MemoryCheckpointer does).
+type ProcessedChecker[ID comparable] interface {
+	IsProcessed(id ID) bool
+}
+
+// Resume passes on source minus the successful items cp has already seen (IDs
+// per idFn), to be fed to the engine of a restarted job. When cp implements
+// ProcessedChecker every processed ID is filtered out; otherwise source is
+// taken as replayed in its original order and fast-forwarded past the item
+// with cp.LastCheckpoint(). Failures and cancels are always passed on.
+//
+// Fast-forwarding is only sound when the checkpointed run processed strictly
+// in input order (a single line, RunSingle or TurnoutOrdered): Checkpointed
+// marks results in completion order, so with several lines earlier inputs
+// still running or failed would be skipped. Use a ProcessedChecker otherwise.
+// When source ends without the checkpoint, ErrCheckpointNotFound is emitted.
+func Resume[T any, ID comparable](ctx context.Context, source <-chan rop.Result[T],
+	cp Checkpointer[ID], idFn func(r T) ID) <-chan rop.Result[T] {
+
+	out := make(chan rop.Result[T], core.GetBufferSize(ctx, 0))
+
+	checker, filtering := cp.(ProcessedChecker[ID])
+	last, forwarding := cp.LastCheckpoint()
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		for r := range source {
+			if r.IsSuccess() {
+				id := idFn(r.Result())
+				switch {
+				case filtering:
+					if checker.IsProcessed(id) {
+						continue
+					}
+				case forwarding:
+					// everything up to the checkpoint is skipped, the checkpoint included
+					forwarding = id != last
+					continue
+				}
+			}
+
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if !filtering && forwarding {
+			select {
+			case out <- rop.Fail[T](fmt.Errorf("%w: %v", ErrCheckpointNotFound, last)):
+			case <-ctx.Done():
+			}
+		}
+	})
+
+	return out
+}
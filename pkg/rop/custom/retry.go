@@ -0,0 +1,184 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+type requeued[In any] struct {
+	input   rop.Result[In]
+	attempt int
+}
+
+// RunWithRetry is Turnout that pushes an item failing in engine back onto an
+// internal requeue, up to attempts tries in total, after waiting backoff(attempt).
+// Unlike lite.Retry the line is free for other items meanwhile. Only terminal
+// failures reach the output, as a *core.RetryError when the item was retried;
+// cancels are never retried. Items waiting for a retry are dropped on cancel.
+func RunWithRetry[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	attempts int, backoff core.Backoff,
+	handlers core.CancellationHandlers[In, Out],
+	onSuccess func(ctx context.Context, in rop.Result[Out]), lines int) <-chan rop.Result[Out] {
+
+	if backoff == nil {
+		backoff = core.ConstantBackoff(0)
+	}
+
+	out := make(chan rop.Result[Out], core.GetBufferOptions(ctx).ChannelSize())
+	feed := make(chan rop.Result[requeued[In]])
+	requeue := make(chan requeued[In])
+	// settled is signaled whenever the last item in the system reaches the output
+	settled := make(chan struct{}, 1)
+	var pending atomic.Int64
+
+	terminal := func() {
+		if pending.Add(-1) == 0 {
+			select {
+			case settled <- struct{}{}:
+			default:
+			}
+		}
+	}
+
+	retrying := func(ctx context.Context, q rop.Result[requeued[In]]) <-chan rop.Result[Out] {
+		item := q.Result()
+		res := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(res)
+
+			r, ok := <-engine(ctx, item.input)
+			if !ok {
+				terminal()
+				return
+			}
+
+			if r.IsSuccess() || r.IsCancel() || !item.input.IsSuccess() {
+				terminal()
+				res <- r
+				return
+			}
+			if item.attempt >= attempts {
+				terminal()
+				if item.attempt > 1 {
+					r = rop.Fail[Out](&core.RetryError{Attempts: item.attempt, Err: r.Err()})
+				}
+				res <- r
+				return
+			}
+
+			// nothing is emitted for now: the line goes on with the next item
+			core.Go(ctx, func() {
+				if core.Sleep(ctx, backoff(item.attempt)) != nil {
+					return
+				}
+				select {
+				case requeue <- requeued[In]{input: item.input, attempt: item.attempt + 1}:
+				case <-ctx.Done():
+				}
+			})
+		})
+
+		return res
+	}
+
+	core.Go(ctx, func() {
+		defer close(feed)
+
+		in := inputCh
+		for in != nil || pending.Load() > 0 {
+			var next requeued[In]
+			select {
+			case <-ctx.Done():
+				return
+			case <-settled:
+				continue
+			case q := <-requeue:
+				next = q
+			case r, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				pending.Add(1)
+				next = requeued[In]{input: r, attempt: 1}
+			}
+
+			select {
+			case feed <- rop.Success(next):
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	// the lines carry the internal feed: hand the hooks of the stage the original input
+	stage, _ := core.GetStageName(ctx)
+	linesCtx := core.WithLocomotiveHooks(ctx, stage, unwrapRequeuedHooks(core.GetLocomotiveHooks[In, Out](ctx)))
+
+	wg := &sync.WaitGroup{}
+	core.StartLines(linesCtx, lines, feed, out, retrying, unwrapRequeued(inputCh, handlers), onSuccess, wg)
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+	})
+
+	return mass.Buffered(ctx, out)
+}
+
+// unwrapRequeued adapts handlers to the internal feed; OnCancel drains the
+// original input, the feed itself is closed on cancel.
+func unwrapRequeued[In, Out any](inputCh <-chan rop.Result[In],
+	handlers core.CancellationHandlers[In, Out]) core.CancellationHandlers[requeued[In], Out] {
+
+	var adapted core.CancellationHandlers[requeued[In], Out]
+	if handlers.OnCancel != nil {
+		adapted.OnCancel = func(ctx context.Context, _ <-chan rop.Result[requeued[In]],
+			outCh chan<- rop.Result[Out]) {
+			handlers.OnCancel(ctx, inputCh, outCh)
+		}
+	}
+	if handlers.OnCancelUnprocessed != nil {
+		adapted.OnCancelUnprocessed = func(ctx context.Context, q rop.Result[requeued[In]],
+			outCh chan<- rop.Result[Out]) {
+			handlers.OnCancelUnprocessed(ctx, q.Result().input, outCh)
+		}
+	}
+	if handlers.OnCancelProcessed != nil {
+		adapted.OnCancelProcessed = func(ctx context.Context, q rop.Result[requeued[In]],
+			processed rop.Result[Out], outCh chan<- rop.Result[Out]) {
+			handlers.OnCancelProcessed(ctx, q.Result().input, processed, outCh)
+		}
+	}
+	return adapted
+}
+
+// unwrapRequeuedHooks adapts hooks to the internal feed, so they only see the
+// original input (once per attempt).
+func unwrapRequeuedHooks[In, Out any](hooks core.LocomotiveHooks[In, Out]) core.LocomotiveHooks[requeued[In], Out] {
+	adapted := core.LocomotiveHooks[requeued[In], Out]{OnEmit: hooks.OnEmit}
+	if hooks.OnReceive != nil {
+		adapted.OnReceive = func(ctx context.Context, q rop.Result[requeued[In]]) {
+			hooks.OnReceive(ctx, q.Result().input)
+		}
+	}
+	if hooks.OnEngineStart != nil {
+		adapted.OnEngineStart = func(ctx context.Context, q rop.Result[requeued[In]]) {
+			hooks.OnEngineStart(ctx, q.Result().input)
+		}
+	}
+	if hooks.OnEngineEnd != nil {
+		adapted.OnEngineEnd = func(ctx context.Context, q rop.Result[requeued[In]], elapsed time.Duration) {
+			hooks.OnEngineEnd(ctx, q.Result().input, elapsed)
+		}
+	}
+	return adapted
+}
@@ -0,0 +1,123 @@
+package custom
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+// Snapshot summarizes a Finally run for reconciliation by the caller.
+type Snapshot[In, Out any] struct {
+	// Emitted holds the finalized values sent on the output, in order
+	Emitted []Out
+	// Unsent holds values finalized but not sent on because of the cancel
+	Unsent []Out
+	// Pending holds the inputs not finalized because of the cancel
+	Pending []rop.Result[In]
+	// Reason is the cancel cause, nil when the run was not interrupted
+	Reason error
+}
+
+// FinallyWithSnapshot is Finally that also delivers a Snapshot once the output
+// is closed. The cancel handlers still run; when OnCancelValues is nil the
+// pending inputs are those waiting in the input at the time of the cancel.
+func FinallyWithSnapshot[In, Out any](ctx context.Context, input <-chan rop.Result[In],
+	handlers mass.FinallyHandlers[In, Out],
+	cancelHandlers mass.FinallyCancelHandlers[In, Out],
+	onSuccessResult func(ctx context.Context, out Out)) (<-chan Out, <-chan Snapshot[In, Out]) {
+
+	var mu sync.Mutex
+	var snapshot Snapshot[In, Out]
+	record := func(update func(s *Snapshot[In, Out])) {
+		mu.Lock()
+		defer mu.Unlock()
+		update(&snapshot)
+	}
+
+	emitted := func(ctx context.Context, out Out) {
+		record(func(s *Snapshot[In, Out]) { s.Emitted = append(s.Emitted, out) })
+		if onSuccessResult != nil {
+			onSuccessResult(ctx, out)
+		}
+	}
+
+	recording := cancelHandlers
+	recording.OnCancelValue = func(ctx context.Context, in rop.Result[In],
+		brokenF func(ctx context.Context, in rop.Result[In]) Out, outCh chan<- Out) {
+		record(func(s *Snapshot[In, Out]) { s.Pending = append(s.Pending, in) })
+		if cancelHandlers.OnCancelValue != nil {
+			cancelHandlers.OnCancelValue(ctx, in, brokenF, outCh)
+		}
+	}
+	recording.OnCancelValues = func(ctx context.Context, inputCh <-chan rop.Result[In],
+		brokenF func(ctx context.Context, in rop.Result[In]) Out, outCh chan<- Out) {
+		if cancelHandlers.OnCancelValues == nil {
+			for _, in := range waiting(inputCh) {
+				record(func(s *Snapshot[In, Out]) { s.Pending = append(s.Pending, in) })
+			}
+			return
+		}
+
+		remaining := make(chan rop.Result[In])
+		core.Go(ctx, func() {
+			defer close(remaining)
+			for in := range inputCh {
+				record(func(s *Snapshot[In, Out]) { s.Pending = append(s.Pending, in) })
+				remaining <- in
+			}
+		})
+		cancelHandlers.OnCancelValues(ctx, remaining, brokenF, outCh)
+		// whatever the handler left unread is pending as well
+		for range remaining {
+		}
+	}
+	recording.OnCancelResult = func(ctx context.Context, out Out, outCh chan<- Out) {
+		record(func(s *Snapshot[In, Out]) { s.Unsent = append(s.Unsent, out) })
+		if cancelHandlers.OnCancelResult != nil {
+			cancelHandlers.OnCancelResult(ctx, out, outCh)
+		}
+	}
+
+	finalized, finished := mass.FinalizingTracked(ctx, input, handlers, recording, emitted)
+
+	out := make(chan Out)
+	snapshotCh := make(chan Snapshot[In, Out], 1)
+	core.Go(ctx, func() {
+		defer close(snapshotCh)
+
+		for v := range finalized {
+			out <- v
+		}
+		close(out)
+		// on cancel the input side may still be recording
+		<-finished
+
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() != nil {
+			snapshot.Reason = context.Cause(ctx)
+		}
+		snapshotCh <- snapshot
+	})
+
+	return out, snapshotCh
+}
+
+// waiting takes the items immediately available in inputCh.
+func waiting[T any](inputCh <-chan T) []T {
+	var items []T
+	for {
+		select {
+		case in, ok := <-inputCh:
+			if !ok {
+				return items
+			}
+			items = append(items, in)
+		default:
+			return items
+		}
+	}
+}
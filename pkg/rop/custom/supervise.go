@@ -0,0 +1,134 @@
+package custom
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// StageHealth is what a Supervisor knows of one stage.
+type StageHealth struct {
+	Name      string
+	Restarts  int
+	LastPanic *rop.PanicError
+	// GaveUp is set once a line of the stage ran out of restarts
+	GaveUp bool
+}
+
+// Health is the aggregate health of a supervised composition. Err is the cause
+// it was stopped with, nil while it runs.
+type Health struct {
+	Healthy bool
+	Stages  []StageHealth
+	Err     error
+}
+
+// Supervisor owns the contexts of a multi-stage composition: stages run under
+// Context(name) restart their panicking lines per the supervisor policy and
+// report to Health; one stage giving up stops all of them.
+type Supervisor struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	policy core.RestartPolicy
+
+	mu     sync.Mutex
+	stages map[string]*StageHealth
+	order  []string
+}
+
+// Supervise starts a Supervisor under ctx; stages may be named up front so
+// Health lists them before their first event.
+func Supervise(ctx context.Context, policy core.RestartPolicy, stages ...string) *Supervisor {
+	supCtx, cancel := context.WithCancelCause(ctx)
+	s := &Supervisor{ctx: supCtx, cancel: cancel, policy: policy, stages: map[string]*StageHealth{}}
+	for _, name := range stages {
+		s.stage(name)
+	}
+	return s
+}
+
+// Context returns the ctx to run the named stage (Run/Turnout/Finally) with.
+// Besides its lines, the goroutines of the stage (such as those running its
+// engine) are supervised: a panic there loses that item and counts as a
+// restart of the stage (reported with workerID -1), the stage giving up once
+// there were more than MaxRestarts of them.
+func (s *Supervisor) Context(stage string) context.Context {
+	s.stage(stage)
+
+	policy := s.policy
+	policy.OnRestart = func(ctx context.Context, workerID int, p *rop.PanicError) {
+		s.record(stage, func(h *StageHealth) {
+			h.Restarts++
+			h.LastPanic = p
+		})
+		if s.policy.OnRestart != nil {
+			s.policy.OnRestart(ctx, workerID, p)
+		}
+	}
+	policy.OnGiveUp = func(ctx context.Context, workerID int, p *rop.PanicError) {
+		s.record(stage, func(h *StageHealth) {
+			h.GaveUp = true
+			h.LastPanic = p
+		})
+		if s.policy.OnGiveUp != nil {
+			s.policy.OnGiveUp(ctx, workerID, p)
+		}
+		s.Stop(&core.StageError{Stage: stage, Err: p})
+	}
+
+	var panics atomic.Int64
+	onPanic := func(p *rop.PanicError) {
+		if int(panics.Add(1)) > policy.MaxRestarts {
+			policy.OnGiveUp(s.ctx, -1, p)
+			return
+		}
+		policy.OnRestart(s.ctx, -1, p)
+	}
+
+	stageCtx := core.WithPanicHandler(core.WithStageName(s.ctx, stage), onPanic)
+	return core.WithRestartPolicy(stageCtx, policy)
+}
+
+// Stop cancels every supervised stage with cause.
+func (s *Supervisor) Stop(cause error) {
+	s.cancel(cause)
+}
+
+func (s *Supervisor) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+func (s *Supervisor) Health() Health {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := Health{Healthy: true, Stages: make([]StageHealth, 0, len(s.order))}
+	for _, name := range s.order {
+		h := *s.stages[name]
+		health.Healthy = health.Healthy && !h.GaveUp
+		health.Stages = append(health.Stages, h)
+	}
+	if s.ctx.Err() != nil {
+		health.Healthy = false
+		health.Err = context.Cause(s.ctx)
+	}
+	return health
+}
+
+func (s *Supervisor) stage(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.stages[name]; !ok {
+		s.stages[name] = &StageHealth{Name: name}
+		s.order = append(s.order, name)
+	}
+}
+
+func (s *Supervisor) record(stage string, update func(h *StageHealth)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(s.stages[stage])
+}
@@ -0,0 +1,21 @@
+package custom
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// WithTimeout gives every item of a Validate/Switch/Map/Try (or other mass
+// based) stage its own deadline of d. An expired item is handed to the
+// onCancel callback of the stage and yields a cancel result carrying the
+// deadline error, while the other items and lines go on.
+func WithTimeout[In, Out any](stage func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	d time.Duration) func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		return stage(core.WithStageTimeout(ctx, d), input)
+	}
+}
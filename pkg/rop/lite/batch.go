@@ -0,0 +1,26 @@
+package lite
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+// Batch groups successful results into batches of up to size values. A batch
+// is flushed when it is full, when maxWait has passed since its first value,
+// or when input closes. Failures and cancels are passed on individually.
+// A size <= 0 disables the size limit; a maxWait <= 0 disables the timer.
+func Batch[T any](ctx context.Context, input <-chan rop.Result[T],
+	size int, maxWait time.Duration) <-chan rop.Result[[]T] {
+	return mass.Batching(ctx, input, size, maxWait, mass.BatchingCancelHandlers[T]{})
+}
+
+// passOn converts a failed or canceled result to another value type.
+func passOn[In, Out any](r rop.Result[In]) rop.Result[Out] {
+	if r.IsCancel() {
+		return rop.Cancel[Out](r.Err())
+	}
+	return rop.Fail[Out](r.Err())
+}
@@ -0,0 +1,71 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// SubscriberPolicy configures one Broadcast output. Buffer is the capacity of
+// its channel; with DropWhenFull a result is dropped for this subscriber when
+// its buffer is full, instead of holding back every other subscriber.
+type SubscriberPolicy struct {
+	Buffer       int
+	DropWhenFull bool
+}
+
+// Broadcast copies every result of input to n channels. policies[i], when given,
+// configures output i; outputs without a policy are unbuffered and blocking,
+// so every such output must be drained.
+func Broadcast[T any](ctx context.Context, input <-chan rop.Result[T], n int,
+	policies ...SubscriberPolicy) []<-chan rop.Result[T] {
+
+	outs := make([]chan rop.Result[T], n)
+	subscribers := make([]<-chan rop.Result[T], n)
+	for i := range outs {
+		var policy SubscriberPolicy
+		if i < len(policies) {
+			policy = policies[i]
+		}
+		outs[i] = make(chan rop.Result[T], policy.Buffer)
+		subscribers[i] = outs[i]
+	}
+
+	core.Go(ctx, func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-input:
+				if !ok {
+					return
+				}
+
+				for i, out := range outs {
+					if i < len(policies) && policies[i].DropWhenFull {
+						select {
+						case out <- r:
+						default:
+						}
+						continue
+					}
+
+					select {
+					case out <- r:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	})
+
+	return subscribers
+}
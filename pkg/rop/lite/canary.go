@@ -0,0 +1,111 @@
+package lite
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type CanaryReport[In, Out any] struct {
+	Input    rop.Result[In]
+	Primary  rop.Result[Out]
+	Canary   rop.Result[Out]
+	TimedOut bool
+	// NoResult is set when the canary finished in time without emitting
+	NoResult bool
+}
+
+// Canary runs the primary engine for every item and, for roughly percent% of
+// them, also runs the canary engine under the given timeout. Only the primary
+// result is emitted; onDivergence is called when the canary result differs
+// (per equal), does not finish in time or emits nothing. A nil equal compares
+// outcomes only.
+func Canary[In, Out any](
+	primary func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	canary func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	percent float64, timeout time.Duration,
+	equal func(primary, canary rop.Result[Out]) bool,
+	onDivergence func(ctx context.Context, report CanaryReport[In, Out])) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+
+	if equal == nil {
+		equal = sameOutcome[Out]
+	}
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+
+		var canaryCh <-chan CanaryReport[In, Out]
+		if percent > 0 && rand.Float64()*100 < percent {
+			canaryCh = runCanary(ctx, input, canary, timeout)
+		}
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			pr, ok := <-primary(ctx, input)
+			if !ok {
+				return
+			}
+			out <- pr
+
+			if canaryCh == nil {
+				return
+			}
+
+			report := <-canaryCh
+			report.Primary = pr
+			diverged := report.TimedOut || report.NoResult || !equal(pr, report.Canary)
+			if diverged && onDivergence != nil {
+				onDivergence(ctx, report)
+			}
+		})
+
+		return out
+	}
+}
+
+func runCanary[In, Out any](ctx context.Context, input rop.Result[In],
+	canary func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	timeout time.Duration) <-chan CanaryReport[In, Out] {
+
+	reportCh := make(chan CanaryReport[In, Out], 1)
+
+	core.Go(ctx, func() {
+		defer close(reportCh)
+
+		canaryCtx, cancel := canaryContext(ctx, timeout)
+		defer cancel()
+
+		report := CanaryReport[In, Out]{Input: input}
+		select {
+		case cr, ok := <-canary(canaryCtx, input):
+			if ok {
+				report.Canary = cr
+			} else if canaryCtx.Err() != nil {
+				report.TimedOut = true
+			} else {
+				report.NoResult = true
+			}
+		case <-canaryCtx.Done():
+			report.TimedOut = true
+		}
+		reportCh <- report
+	})
+
+	return reportCh
+}
+
+func canaryContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func sameOutcome[T any](a, b rop.Result[T]) bool {
+	return a.IsSuccess() == b.IsSuccess() && a.IsCancel() == b.IsCancel()
+}
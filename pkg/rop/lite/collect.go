@@ -0,0 +1,45 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Summary counts the outcomes seen by Collect.
+type Summary struct {
+	Total     int
+	Succeeded int
+	Failed    int
+	Canceled  int
+}
+
+// Collect drains input (until it closes or ctx is done) and classifies every
+// result into successes, failures and cancels, with a Summary of the counts.
+func Collect[T any](ctx context.Context, input <-chan rop.Result[T]) (successes []T,
+	failures []error, cancels []error, summary Summary) {
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case r, ok := <-input:
+			if !ok {
+				return
+			}
+
+			summary.Total++
+			switch {
+			case r.IsSuccess():
+				successes = append(successes, r.Result())
+				summary.Succeeded++
+			case r.IsCancel():
+				cancels = append(cancels, r.Err())
+				summary.Canceled++
+			default:
+				failures = append(failures, r.Err())
+				summary.Failed++
+			}
+		}
+	}
+}
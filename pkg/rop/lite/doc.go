@@ -5,9 +5,35 @@
 // Common usage:
 // - Run: execute an engine over an input channel with a fixed number of lines
 // - Validate/Try/Switch/Map/DoubleMap: lift solo operations over channels
+// - Filter: drop successful items not matching a predicate
 // - Turnout: compose stages with configurable parallelism
+//   (core.WithSemaphore replaces fixed lines with one dispatcher per stage sharing a semaphore)
+// - TryStage/MapStage/StageOf + Pipe: run plain functions over a slice without channel plumbing
+//   (output channel capacity is taken from core.WithBufferOptions on the stage ctx;
+//   core.WithBufferPolicy adds drop-oldest/drop-newest/fail handling when it is full)
+// - Named: name a stage so its failures (core.StageError) and progress reports identify it
+//   (core.WithLocomotiveHooks observes each item's receive, engine start/end and emit for a named stage)
+// - Recovered: turn panics inside an engine into failures carrying the stack
+// - Retry: re-feed failed items through an engine with a backoff
+// - WithTimeout: give each item its own deadline, canceling only that item on expiry
+// - FlatMap: expand one input into many results (e.g. one file into many records)
+// - GroupBy: Turnout that keeps per-key order by routing each key to one line
 // - Finally: map Result[In] to Out on completion
+//   (Run/Turnout/Finally report progress when the ctx carries core.WithProgressOptions)
+// - Run/Turnout/Finally also take core.Option values (core.WithWorkers/WithProcessRemaining/
+//   WithBuffer/WithName), which override the matching ctx options
+// - Broadcast: copy one result channel to several downstream pipelines
+// - ZipCh: join two result channels pairwise
+// - Partition/SplitByOutcome: split a result channel into per-branch channels
+// - Collect: drain a result channel into successes, failures, cancels and a Summary
+// - SortBy: emit a bounded batch of results in a deterministic order
+// - Reduce: fold successful results into a single value without collecting them
+// - Batch: group successful results into size/time bounded batches for bulk writes
+// - Window: aggregate successful results per tumbling time window
+// - Sample/SampleEvery: pass on a random share or every n-th result, skipping the rest
+// - Canary: shadow a share of items through an alternate engine and report divergence
 //
-// For advanced cancellation routing and multi-worker control, see package mass
-// and custom.
+// Sources, sinks and the context options read by Run/Turnout are in package
+// core. For advanced cancellation routing and multi-worker control, see package
+// mass and custom.
 package lite
\ No newline at end of file
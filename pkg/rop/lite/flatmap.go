@@ -0,0 +1,60 @@
+package lite
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// FlatMap expands every successful input into the results returned by expand
+// (e.g. one file into many records) and emits them in order, using the given
+// number of lines. Failures and cancels are passed on as a single result.
+func FlatMap[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	expand func(ctx context.Context, r In) []rop.Result[Out], lines int) <-chan rop.Result[Out] {
+
+	engine := func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out])
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			if !input.IsSuccess() {
+				select {
+				case out <- passOn[In, Out](input):
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			for _, r := range expand(ctx, input.Result()) {
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+
+		return out
+	}
+
+	out := make(chan rop.Result[Out], core.GetBufferSize(ctx, 0))
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < lines; i++ {
+		wg.Add(1)
+		core.Go(ctx, func() {
+			defer wg.Done()
+			streamLine(ctx, inputCh, out, engine)
+		})
+	}
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+	})
+
+	return out
+}
@@ -0,0 +1,87 @@
+package lite
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// GroupBy is Turnout with items routed by key: every item with the same key
+// goes to the same line, so items sharing a key are processed in input order
+// while different keys are processed in parallel across lines.
+func GroupBy[In, Out any, K comparable](ctx context.Context, inputCh <-chan rop.Result[In],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	keyFn func(r In) K, lines int) <-chan rop.Result[Out] {
+
+	if lines < 1 {
+		lines = 1
+	}
+
+	out := make(chan rop.Result[Out], core.GetBufferSize(ctx, 0))
+	wg := &sync.WaitGroup{}
+
+	lineChs := make([]chan rop.Result[In], lines)
+	for i := range lineChs {
+		lineCh := make(chan rop.Result[In])
+		lineChs[i] = lineCh
+
+		wg.Add(1)
+		core.Go(ctx, func() {
+			defer wg.Done()
+			streamLine(ctx, lineCh, out, engine)
+		})
+	}
+
+	seed := maphash.MakeSeed()
+	core.Go(ctx, func() {
+		defer func() {
+			for _, lineCh := range lineChs {
+				close(lineCh)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case in, ok := <-inputCh:
+				if !ok {
+					return
+				}
+
+				line := maphash.Comparable(seed, keyFn(in.Result())) % uint64(lines)
+				select {
+				case lineChs[line] <- in:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	core.Go(ctx, func() {
+		wg.Wait()
+		close(out)
+	})
+
+	return out
+}
+
+// streamLine runs engine over every item of its line and forwards everything
+// engine emits. Unlike Locomotive it keeps running when engine emits nothing
+// and does not stop after the first value.
+func streamLine[In, Out any](ctx context.Context, lineCh <-chan rop.Result[In], out chan<- rop.Result[Out],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out]) {
+
+	for in := range lineCh {
+		for pr := range engine(ctx, in) {
+			select {
+			case out <- pr:
+			case <-ctx.Done():
+			}
+		}
+	}
+}
@@ -11,42 +11,48 @@ import (
 
 func Run[T any](ctx context.Context, inputCh <-chan rop.Result[T],
 	engine func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T],
-	lines int) <-chan rop.Result[T] {
+	lines int, opts ...core.Option) <-chan rop.Result[T] {
 
-	out := make(chan rop.Result[T])
+	ctx, options := core.ApplyOptions(ctx, opts...)
+	lines = options.Lines(lines)
+
+	out := make(chan rop.Result[T], options.Buffer.ChannelSize())
 	wg := &sync.WaitGroup{}
+	progress := core.NewProgressTracker(ctx)
 
-	for i := 0; i < lines; i++ {
-		wg.Add(1)
-		go core.Locomotive(ctx, inputCh, out, engine, core.CancellationHandlers[T, T]{}, nil, wg)
-	}
+	core.StartLines(ctx, lines, inputCh, out, engine, core.CancellationHandlers[T, T]{},
+		core.RecordResult[T](progress), wg)
 
-	go func() {
+	core.Go(ctx, func() {
 		wg.Wait()
+		progress.Finish(ctx)
 		close(out)
-	}()
+	})
 
-	return out
+	return mass.BufferedWith(ctx, out, options.Buffer)
 }
 
 func Turnout[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
-	lines int) <-chan rop.Result[Out] {
+	lines int, opts ...core.Option) <-chan rop.Result[Out] {
+
+	ctx, options := core.ApplyOptions(ctx, opts...)
+	lines = options.Lines(lines)
 
-	out := make(chan rop.Result[Out])
+	out := make(chan rop.Result[Out], options.Buffer.ChannelSize())
 	wg := &sync.WaitGroup{}
+	progress := core.NewProgressTracker(ctx)
 
-	for i := 0; i < lines; i++ {
-		wg.Add(1)
-		go core.Locomotive(ctx, inputCh, out, engine, core.CancellationHandlers[In, Out]{}, nil, wg)
-	}
+	core.StartLines(ctx, lines, inputCh, out, engine, core.CancellationHandlers[In, Out]{},
+		core.RecordResult[Out](progress), wg)
 
-	go func() {
+	core.Go(ctx, func() {
 		wg.Wait()
+		progress.Finish(ctx)
 		close(out)
-	}()
+	})
 
-	return out
+	return mass.BufferedWith(ctx, out, options.Buffer)
 }
 
 func Validate[T any](validate func(ctx context.Context, in T) (valid bool, errMsg string)) func(ctx context.Context,
@@ -87,6 +93,23 @@ func Tee[T any](sideEffect func(ctx context.Context, r rop.Result[T])) func(ctx
 	}
 }
 
+// KeyedTee runs sideEffect serialized per key (keyFn of the successful value).
+func KeyedTee[T any, K comparable](keyFn func(r T) K,
+	sideEffect func(ctx context.Context, r rop.Result[T])) func(ctx context.Context,
+	input rop.Result[T]) <-chan rop.Result[T] {
+	locks := core.NewKeyedMutex[K]()
+	serialized := func(ctx context.Context, r rop.Result[T]) {
+		key := keyFn(r.Result())
+		locks.Lock(key)
+		defer locks.Unlock(key)
+		sideEffect(ctx, r)
+	}
+
+	return func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T] {
+		return mass.Teeing(ctx, input, serialized, nil)
+	}
+}
+
 func DoubleTee[T any](sideEffect func(ctx context.Context, r T),
 	sideEffectOnError func(ctx context.Context, err error),
 	sideEffectOnCancel func(ctx context.Context, err error)) func(ctx context.Context,
@@ -96,6 +119,13 @@ func DoubleTee[T any](sideEffect func(ctx context.Context, r T),
 	}
 }
 
+func Filter[T any](pred func(ctx context.Context, r T) bool) func(ctx context.Context,
+	input rop.Result[T]) <-chan rop.Result[T] {
+	return func(ctx context.Context, input rop.Result[T]) <-chan rop.Result[T] {
+		return mass.Filtering(ctx, input, pred, nil, nil)
+	}
+}
+
 func Try[In, Out any](
 	onTryExecute func(ctx context.Context, r In) (Out, error)) func(ctx context.Context,
 	input rop.Result[In]) <-chan rop.Result[Out] {
@@ -104,7 +134,23 @@ func Try[In, Out any](
 	}
 }
 
+func Step[In, Out any](step func(ctx context.Context, input rop.Result[In]) rop.Result[Out]) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		return mass.Stepping(ctx, input, step, nil)
+	}
+}
+
 func Finally[In, Out any](ctx context.Context, input <-chan rop.Result[In],
-	handlers mass.FinallyHandlers[In, Out]) <-chan Out {
+	handlers mass.FinallyHandlers[In, Out], opts ...core.Option) <-chan Out {
+	ctx, _ = core.ApplyOptions(ctx, opts...)
+	if progress := core.NewProgressTracker(ctx); progress != nil {
+		input = core.Track(ctx, input, progress)
+	}
 	return mass.Finalizing(ctx, input, handlers, mass.FinallyCancelHandlers[In, Out]{}, nil)
 }
+
+func FinallyVerified[In, Out any](ctx context.Context, input <-chan rop.Result[In],
+	handlers mass.FinallyHandlers[In, Out], trailer <-chan core.Trailer) (<-chan Out, <-chan error) {
+	return core.VerifyTrailer(ctx, Finally(ctx, input, handlers), trailer, nil)
+}
@@ -0,0 +1,69 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestBatch_FlushOnSizeAndClose(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int])
+	go func() {
+		defer close(in)
+		for i := 1; i <= 5; i++ {
+			in <- rop.Success(i)
+		}
+		in <- rop.Fail[int](errors.New("bad"))
+		in <- rop.Success(6)
+	}()
+
+	var sizes []int
+	failed := 0
+	for r := range Batch(ctx, in, 2, time.Minute) {
+		if r.IsSuccess() {
+			sizes = append(sizes, len(r.Result()))
+		} else {
+			failed++
+		}
+	}
+
+	// [1 2] [3 4] then the failure passes through, and [5 6] flushes on size
+	if len(sizes) != 3 || sizes[0] != 2 || sizes[1] != 2 || sizes[2] != 2 || failed != 1 {
+		t.Fatalf("unexpected batches %v with %d failures", sizes, failed)
+	}
+}
+
+func TestBatch_FlushOnMaxWait(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int])
+	out := Batch(ctx, in, 100, 20*time.Millisecond)
+
+	in <- rop.Success(1)
+	in <- rop.Success(2)
+
+	select {
+	case r := <-out:
+		if !r.IsSuccess() || len(r.Result()) != 2 {
+			t.Fatalf("expected a timed batch of 2, got: success=%v, val=%v", r.IsSuccess(), r.Result())
+		}
+	case <-ctx.Done():
+		t.Fatalf("batch was not flushed after maxWait")
+	}
+
+	close(in)
+	if _, ok := <-out; ok {
+		t.Fatalf("expected no trailing batch")
+	}
+}
@@ -0,0 +1,53 @@
+package lite
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestBroadcast_AllSubscribersSeeEveryResult(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	subs := Broadcast(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3}), 2)
+
+	var persisted, metrics []rop.Result[int]
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go drain(wg, subs[0], &persisted)
+	go drain(wg, subs[1], &metrics)
+	wg.Wait()
+
+	if len(persisted) != 3 || len(metrics) != 3 {
+		t.Fatalf("expected 3 results per subscriber, got %d and %d", len(persisted), len(metrics))
+	}
+}
+
+func TestBroadcast_DropWhenFull(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	subs := Broadcast(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5}), 2,
+		SubscriberPolicy{},
+		SubscriberPolicy{Buffer: 1, DropWhenFull: true})
+
+	// only the blocking subscriber is read until the stream ends
+	main := core.FromChanMany(ctx, subs[0])
+	sampled := core.FromChanMany(ctx, subs[1])
+
+	if len(main) != 5 {
+		t.Fatalf("expected 5 results for the blocking subscriber, got %d", len(main))
+	}
+	if len(sampled) != 1 {
+		t.Fatalf("expected the slow subscriber to keep only its buffered result, got %d", len(sampled))
+	}
+}
@@ -0,0 +1,95 @@
+package lite
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRun_BufferedOutput(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var processed atomic.Int32
+	engine := Map(func(ctx context.Context, v int) int {
+		processed.Add(1)
+		return v
+	})
+
+	out := Run(core.WithBufferOptions(ctx, 5), core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5}), engine, 1)
+
+	// nobody reads out yet: only a buffered stage can run through all items
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for processed.Load() < 5 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := processed.Load(); n != 5 {
+		t.Fatalf("expected all 5 items processed ahead of the reader, got %d", n)
+	}
+	if n := len(core.FromChanMany(ctx, out)); n != 5 {
+		t.Fatalf("expected 5 results, got %d", n)
+	}
+}
+
+func TestRun_BufferPolicyFromContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	for v := 1; v <= 5; v++ {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	out := Run(core.WithBufferPolicy(ctx, 1, core.OverflowDropNewest), in,
+		Map(func(ctx context.Context, v int) int { return v }), 1)
+	waitDrained(t, in)
+	time.Sleep(20 * time.Millisecond)
+
+	if n := len(core.FromChanMany(ctx, out)); n >= 5 || n < 1 {
+		t.Fatalf("expected some results dropped, got %d", n)
+	}
+}
+
+func waitDrained(t *testing.T, in chan rop.Result[int]) {
+	t.Helper()
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for len(in) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(in) > 0 {
+		t.Fatalf("expected the input drained, %d left", len(in))
+	}
+}
+
+func TestRun_BufferPolicyKeepsSize(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	for v := 1; v <= 5; v++ {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	out := Run(core.WithBufferPolicy(ctx, 2, core.OverflowDropNewest), in,
+		Map(func(ctx context.Context, v int) int { return v }), 1)
+	waitDrained(t, in)
+	time.Sleep(20 * time.Millisecond)
+
+	// the output channel under the policy is unbuffered, so only the buffer holds results
+	got := core.FromChanMany(ctx, out)
+	if len(got) != 2 || got[0].Result() != 1 || got[1].Result() != 2 {
+		t.Fatalf("expected only the first 2 results kept, got %v", got)
+	}
+}
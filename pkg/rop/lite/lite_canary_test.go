@@ -0,0 +1,146 @@
+package lite
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestCanary_EmitsPrimaryAndReportsDivergence(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	mu := &sync.Mutex{}
+	var reports []CanaryReport[int, int]
+
+	engine := Canary[int, int](
+		Map(func(ctx context.Context, r int) int { return r * 2 }),
+		Map(func(ctx context.Context, r int) int {
+			if r == 3 {
+				return -1
+			}
+			return r * 2
+		}),
+		100, time.Second,
+		func(primary, canary rop.Result[int]) bool { return primary.Result() == canary.Result() },
+		func(ctx context.Context, report CanaryReport[int, int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, report)
+		})
+
+	sum := 0
+	for r := range Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine, 2) {
+		if !r.IsSuccess() {
+			t.Fatalf("unexpected failure: %v", r.Err())
+		}
+		sum += r.Result()
+	}
+	if sum != 20 {
+		t.Fatalf("expected primary results only (sum 20), got %d", sum)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(reports)
+		mu.Unlock()
+		if n > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 divergence report, got %d", len(reports))
+	}
+	if reports[0].Input.Result() != 3 || reports[0].Primary.Result() != 6 || reports[0].Canary.Result() != -1 {
+		t.Fatalf("unexpected report: in=%v primary=%v canary=%v",
+			reports[0].Input.Result(), reports[0].Primary.Result(), reports[0].Canary.Result())
+	}
+}
+
+func TestCanary_TimeoutIsReported(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reported := make(chan CanaryReport[int, int], 1)
+
+	engine := Canary[int, int](
+		Map(func(ctx context.Context, r int) int { return r }),
+		func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+			out := make(chan rop.Result[int])
+			go func() {
+				defer close(out)
+				<-ctx.Done()
+			}()
+			return out
+		},
+		100, 10*time.Millisecond, nil,
+		func(ctx context.Context, report CanaryReport[int, int]) { reported <- report })
+
+	res := <-engine(ctx, rop.Success(5))
+	if !res.IsSuccess() || res.Result() != 5 {
+		t.Fatalf("expected primary success 5, got success=%v val=%v", res.IsSuccess(), res.Result())
+	}
+
+	select {
+	case report := <-reported:
+		if !report.TimedOut {
+			t.Fatalf("expected timed out report")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected divergence report on canary timeout")
+	}
+}
+
+func TestCanary_ZeroPercentSkipsCanary(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+	engine := Canary[int, int](
+		Map(func(ctx context.Context, r int) int { return r }),
+		Map(func(ctx context.Context, r int) int { called = true; return r }),
+		0, time.Second, nil, nil)
+
+	for i := 0; i < 10; i++ {
+		<-engine(ctx, rop.Success(i))
+	}
+	if called {
+		t.Fatalf("canary must not run at 0 percent")
+	}
+}
+
+func TestCanary_NoResultIsReported(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	reports := make(chan CanaryReport[int, int], 1)
+	engine := Canary[int, int](
+		Map(func(ctx context.Context, r int) int { return r }),
+		Filter(func(ctx context.Context, r int) bool { return false }),
+		100, 0, nil,
+		func(ctx context.Context, report CanaryReport[int, int]) { reports <- report })
+
+	if r := <-engine(ctx, rop.Success(1)); r.Result() != 1 {
+		t.Fatalf("expected the primary result, got %v", r)
+	}
+
+	select {
+	case report := <-reports:
+		if !report.NoResult || report.TimedOut {
+			t.Fatalf("expected a no-result report, got %+v", report)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected divergence report on a canary without result")
+	}
+}
@@ -0,0 +1,37 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	in <- rop.Success(1)
+	in <- rop.Fail[int](errors.New("bad"))
+	in <- rop.Success(2)
+	in <- rop.Cancel[int](context.Canceled)
+	in <- rop.Success(3)
+	close(in)
+
+	successes, failures, cancels, summary := Collect(ctx, in)
+
+	if len(successes) != 3 || successes[0] != 1 || successes[2] != 3 {
+		t.Fatalf("unexpected successes %v", successes)
+	}
+	if len(failures) != 1 || failures[0].Error() != "bad" || len(cancels) != 1 {
+		t.Fatalf("unexpected failures %v / cancels %v", failures, cancels)
+	}
+	if summary != (Summary{Total: 5, Succeeded: 3, Failed: 1, Canceled: 1}) {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
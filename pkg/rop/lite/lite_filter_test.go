@@ -0,0 +1,49 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 6)
+	for _, v := range []int{1, 2, 3, 4, 5} {
+		in <- rop.Success(v)
+	}
+	in <- rop.Fail[int](errors.New("bad"))
+	close(in)
+
+	even := Filter(func(ctx context.Context, v int) bool { return v%2 == 0 })
+	successes, failures, _, _ := Collect(ctx, Run(ctx, in, even, 2))
+
+	if len(successes) != 2 || len(failures) != 1 {
+		t.Fatalf("expected 2 even values and the failure, got %v and %v", successes, failures)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("pipeline stalled until the deadline")
+	}
+}
+
+func TestFilter_Fused(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(core.WithExecutionOptions(context.Background(), core.ExecuteFused), time.Second)
+	defer cancel()
+
+	even := Filter(func(ctx context.Context, v int) bool { return v%2 == 0 })
+	successes, _, _, _ := Collect(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), even, 1))
+
+	if len(successes) != 2 {
+		t.Fatalf("expected 2 even values, got %v", successes)
+	}
+}
@@ -0,0 +1,52 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+func finallyInput() <-chan rop.Result[int] {
+	in := make(chan rop.Result[int], 3)
+	in <- rop.Success(7)
+	in <- rop.Fail[int](errors.New("bad"))
+	in <- rop.Cancel[int](context.Canceled)
+	close(in)
+	return in
+}
+
+func TestFinally_DefaultHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	out := core.FromChanMany(ctx, Finally(ctx, finallyInput(),
+		mass.DefaultFinallyHandlers(func(ctx context.Context, r int) string { return strconv.Itoa(r) })))
+	sort.Strings(out)
+
+	if len(out) != 3 || out[0] != "" || out[1] != "" || out[2] != "7" {
+		t.Fatalf("expected [\"\" \"\" \"7\"], got %q", out)
+	}
+}
+
+func TestFinally_IdentityHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	out := core.FromChanMany(ctx, Finally(ctx, finallyInput(), mass.IdentityFinallyHandlers[int]()))
+	sort.Ints(out)
+
+	if len(out) != 3 || out[0] != 0 || out[1] != 0 || out[2] != 7 {
+		t.Fatalf("expected [0 0 7], got %v", out)
+	}
+}
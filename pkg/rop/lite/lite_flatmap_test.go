@@ -0,0 +1,79 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func splitLines(ctx context.Context, file string) []rop.Result[string] {
+	var records []rop.Result[string]
+	for _, line := range strings.Split(file, "\n") {
+		records = append(records, rop.Success(line))
+	}
+	return records
+}
+
+func TestFlatMap_Expands(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[string], 3)
+	in <- rop.Success("a\nb\nc")
+	in <- rop.Fail[string](errors.New("unreadable"))
+	in <- rop.Success("d")
+	close(in)
+
+	records, failed := 0, 0
+	for r := range FlatMap(ctx, in, splitLines, 2) {
+		if r.IsSuccess() {
+			records++
+		} else {
+			failed++
+		}
+	}
+
+	if records != 4 || failed != 1 {
+		t.Fatalf("expected 4 records and 1 failure, got %d and %d", records, failed)
+	}
+}
+
+func TestFlatMap_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int], 1)
+	in <- rop.Success(1000)
+	close(in)
+
+	out := FlatMap(ctx, in, func(ctx context.Context, n int) []rop.Result[int] {
+		records := make([]rop.Result[int], n)
+		for i := range records {
+			records[i] = rop.Success(i)
+		}
+		return records
+	}, 1)
+
+	<-out
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range out {
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("FlatMap did not stop after cancel")
+	}
+}
@@ -0,0 +1,101 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+func fusedTestPipeline(ctx context.Context, input []int) []int {
+	validated := Run(ctx, core.ToChanManyResults(ctx, input),
+		Validate(func(ctx context.Context, v int) (bool, string) { return v%5 != 0, "multiple of 5" }), 4)
+	tried := Turnout(ctx, validated, Try(func(ctx context.Context, v int) (int, error) {
+		if v%7 == 0 {
+			return 0, errors.New("multiple of 7")
+		}
+		return v * 2, nil
+	}), 4)
+
+	var values []int
+	for r := range Turnout(ctx, tried, Map(func(ctx context.Context, v int) int { return v + 1 }), 4) {
+		if r.IsSuccess() {
+			values = append(values, r.Result())
+		}
+	}
+	sort.Ints(values)
+	return values
+}
+
+func TestFusedExecution_SameResults(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	input := make([]int, 100)
+	for i := range input {
+		input[i] = i + 1
+	}
+
+	async := fusedTestPipeline(ctx, input)
+	fused := fusedTestPipeline(core.WithExecutionOptions(ctx, core.ExecuteFused), input)
+
+	if len(async) == 0 || len(async) != len(fused) {
+		t.Fatalf("expected equal non-empty results, got %d and %d", len(async), len(fused))
+	}
+	for i := range async {
+		if async[i] != fused[i] {
+			t.Fatalf("results differ at %d: %d vs %d", i, async[i], fused[i])
+		}
+	}
+}
+
+func TestFusedExecution_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(core.WithExecutionOptions(context.Background(), core.ExecuteFused))
+	cancel()
+
+	called, canceled := 0, 0
+	out := mass.Mapping(ctx, rop.Success(1),
+		func(ctx context.Context, v int) int {
+			called++
+			return v
+		},
+		func(ctx context.Context, in rop.Result[int]) { canceled++ })
+
+	if _, ok := <-out; ok || called != 0 || canceled != 1 {
+		t.Fatalf("expected no result and a single onCancel, got called=%d canceled=%d", called, canceled)
+	}
+}
+
+func benchmarkExecution(b *testing.B, mode core.ExecutionMode) {
+	ctx := core.WithExecutionOptions(context.Background(), mode)
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+	engine := Map(func(ctx context.Context, v int) int { return v * 2 })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range Run(ctx, core.ToChanManyResults(ctx, input), engine, 4) {
+			// Consume all results
+		}
+	}
+}
+
+func BenchmarkExecution_Async(b *testing.B) {
+	benchmarkExecution(b, core.ExecuteAsync)
+}
+
+func BenchmarkExecution_Fused(b *testing.B) {
+	benchmarkExecution(b, core.ExecuteFused)
+}
@@ -0,0 +1,77 @@
+package lite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type event struct {
+	Key string
+	Seq int
+}
+
+func TestGroupBy_PerKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	var events []event
+	for seq := 0; seq < 20; seq++ {
+		for _, key := range []string{"a", "b", "c", "d"} {
+			events = append(events, event{Key: key, Seq: seq})
+		}
+	}
+
+	engine := Map(func(ctx context.Context, e event) event {
+		// later items of a key finish faster, so only routing can keep them ordered
+		time.Sleep(time.Duration(20-e.Seq) * 100 * time.Microsecond)
+		return e
+	})
+
+	last := map[string]int{}
+	count := 0
+	for r := range GroupBy(ctx, core.ToChanManyResults(ctx, events), engine,
+		func(e event) string { return e.Key }, 4) {
+
+		e := r.Result()
+		if prev, ok := last[e.Key]; ok && e.Seq != prev+1 {
+			t.Fatalf("key %s out of order: %d after %d", e.Key, e.Seq, prev)
+		}
+		last[e.Key] = e.Seq
+		count++
+	}
+
+	if count != len(events) {
+		t.Fatalf("expected %d results, got %d", len(events), count)
+	}
+}
+
+func TestGroupBy_EngineWithoutOutput(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	dropOdd := func(ctx context.Context, in rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int], 1)
+		if in.Result()%2 == 0 {
+			out <- in
+		}
+		close(out)
+		return out
+	}
+
+	count := 0
+	for range GroupBy(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5, 6}), dropOdd,
+		func(v int) int { return v % 3 }, 2) {
+		count++
+	}
+	if count != 3 || ctx.Err() != nil {
+		t.Fatalf("expected 3 results before the deadline, got %d (ctx err: %v)", count, ctx.Err())
+	}
+}
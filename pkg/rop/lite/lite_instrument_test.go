@@ -0,0 +1,109 @@
+package lite
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRun_LocomotiveHooks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	counts := map[string]int{}
+	var latencies []time.Duration
+	count := func(hook string) {
+		mu.Lock()
+		defer mu.Unlock()
+		counts[hook]++
+	}
+
+	stageCtx := core.WithLocomotiveHooks(ctx, "render", core.LocomotiveHooks[int, string]{
+		OnReceive:     func(ctx context.Context, in rop.Result[int]) { count("receive") },
+		OnEngineStart: func(ctx context.Context, in rop.Result[int]) { count("start") },
+		OnEngineEnd: func(ctx context.Context, in rop.Result[int], elapsed time.Duration) {
+			count("end")
+		},
+		OnEmit: func(ctx context.Context, out rop.Result[string], latency time.Duration) {
+			count("emit")
+			mu.Lock()
+			defer mu.Unlock()
+			latencies = append(latencies, latency)
+		},
+	})
+
+	engine := Map(func(ctx context.Context, v int) string {
+		time.Sleep(5 * time.Millisecond)
+		return "v"
+	})
+	results := core.FromChanMany(ctx, Turnout(stageCtx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine, 2,
+		core.WithName("render")))
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, hook := range []string{"receive", "start", "end", "emit"} {
+		if counts[hook] != 4 {
+			t.Fatalf("expected %s called 4 times, got %d", hook, counts[hook])
+		}
+	}
+	for _, latency := range latencies {
+		if latency < 5*time.Millisecond {
+			t.Fatalf("expected the emit latency to cover the engine, got %v", latency)
+		}
+	}
+}
+
+func TestRun_LocomotiveHooksOtherStagesIgnored(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var called atomic.Bool
+	ctx = core.WithLocomotiveHooks(ctx, "parse", core.LocomotiveHooks[int, int]{
+		OnReceive: func(ctx context.Context, in rop.Result[int]) { called.Store(true) },
+	})
+
+	identity := Map(func(ctx context.Context, v int) int { return v })
+	results := core.FromChanMany(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1, 2}), identity, 1,
+		core.WithName("store")))
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if called.Load() {
+		t.Fatalf("expected the hooks of another stage to be skipped")
+	}
+}
+
+func TestRun_LocomotiveHooksOtherTypesIgnored(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var called atomic.Bool
+	ctx = core.WithLocomotiveHooks(ctx, "parse", core.LocomotiveHooks[string, string]{
+		OnReceive: func(ctx context.Context, in rop.Result[string]) { called.Store(true) },
+	})
+
+	identity := Map(func(ctx context.Context, v int) int { return v })
+	results := core.FromChanMany(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1}), identity, 1,
+		core.WithName("parse")))
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if called.Load() {
+		t.Fatalf("expected the hooks of other types to be skipped")
+	}
+}
@@ -0,0 +1,68 @@
+package lite
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestKeyedTee_SerializesPerKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inputs := make([]int, 40)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	active := make(map[int]*int32)
+	for k := 0; k < 4; k++ {
+		active[k] = new(int32)
+	}
+	var overlaps, maxParallel, running int32
+	mu := &sync.Mutex{}
+
+	engine := KeyedTee(func(r int) int { return r % 4 },
+		func(ctx context.Context, r rop.Result[int]) {
+			key := r.Result() % 4
+			if atomic.AddInt32(active[key], 1) > 1 {
+				atomic.AddInt32(&overlaps, 1)
+			}
+			n := atomic.AddInt32(&running, 1)
+			mu.Lock()
+			if n > maxParallel {
+				maxParallel = n
+			}
+			mu.Unlock()
+
+			time.Sleep(2 * time.Millisecond)
+
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(active[key], -1)
+		})
+
+	count := 0
+	for r := range Run(ctx, core.ToChanManyResults(ctx, inputs), engine, 8) {
+		if !r.IsSuccess() {
+			t.Fatalf("unexpected failure: %v", r.Err())
+		}
+		count++
+	}
+
+	if count != len(inputs) {
+		t.Fatalf("expected %d results, got %d", len(inputs), count)
+	}
+	if overlaps != 0 {
+		t.Fatalf("expected no concurrent side effects for the same key, got %d overlaps", overlaps)
+	}
+	if maxParallel < 2 {
+		t.Fatalf("expected side effects for different keys to run in parallel, max=%d", maxParallel)
+	}
+}
@@ -0,0 +1,62 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestNamed_AttributesFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	parse := Named("parse", Try(func(ctx context.Context, s string) (int, error) {
+		if s == "x" {
+			return 0, errors.New("not a number")
+		}
+		return len(s), nil
+	}))
+	store := Named("store", Switch(func(ctx context.Context, n int) rop.Result[int] {
+		if n > 2 {
+			return rop.Fail[int](errors.New("too long"))
+		}
+		return rop.Success(n)
+	}))
+
+	parsed := Turnout(ctx, core.ToChanManyResults(ctx, []string{"x", "ab", "abc"}), parse, 2)
+	_, failures, _, _ := Collect(ctx, Run(ctx, parsed, store, 2))
+
+	stages := map[string]bool{}
+	for _, err := range failures {
+		stage, ok := core.FailedStage(err)
+		if !ok {
+			t.Fatalf("expected failure to name its stage, got %v", err)
+		}
+		stages[stage] = true
+	}
+	if len(failures) != 2 || !stages["parse"] || !stages["store"] {
+		t.Fatalf("expected one failure per stage, got %v", failures)
+	}
+}
+
+func TestNamed_ProgressCarriesStage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	log := &progressLog{}
+	stageCtx := core.WithProgressOptions(core.WithStageName(ctx, "double"), 0, 0, log.record)
+	core.FromChanMany(ctx, Run(stageCtx, core.ToChanManyResults(ctx, []int{1, 2}),
+		Map(func(ctx context.Context, v int) int { return v * 2 }), 1))
+
+	if final := log.last(); final.Stage != "double" || final.Processed != 2 {
+		t.Fatalf("unexpected final report %+v", final)
+	}
+}
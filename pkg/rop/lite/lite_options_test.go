@@ -0,0 +1,109 @@
+package lite
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+func TestRun_TypedOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var running, peak atomic.Int32
+	var names atomic.Int32
+	engine := Map(func(ctx context.Context, v int) int {
+		if name, ok := core.GetStageName(ctx); ok && name == "double" {
+			names.Add(1)
+		}
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return v * 2
+	})
+
+	out := Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5, 6}), engine, 1,
+		core.WithWorkers(3), core.WithName("double"), core.WithBuffer(6))
+
+	if n := len(core.FromChanMany(ctx, out)); n != 6 {
+		t.Fatalf("expected 6 results, got %d", n)
+	}
+	if p := peak.Load(); p != 3 {
+		t.Fatalf("expected 3 lines from WithWorkers, got peak %d", p)
+	}
+	if n := names.Load(); n != 6 {
+		t.Fatalf("expected the stage name on every item, got %d", n)
+	}
+}
+
+func TestFinally_TypedOptionsOverrideContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ctx = core.WithProcessOptions(ctx, true)
+	var processRemaining atomic.Bool
+	out := Finally(ctx, core.ToChanManyResults(ctx, []int{1}), mass.FinallyHandlers[int, int]{
+		OnSuccess: func(ctx context.Context, r int) int {
+			processRemaining.Store(core.IsProcessRemainingEnabled(ctx, true))
+			return r
+		},
+	}, core.WithProcessRemaining(false))
+
+	if got := core.FromChanMany(ctx, out); len(got) != 1 {
+		t.Fatalf("expected 1 result, got %v", got)
+	}
+	if processRemaining.Load() {
+		t.Fatalf("expected WithProcessRemaining(false) to override the ctx value")
+	}
+}
+
+func TestOptions_ZeroKeepsContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := core.WithBufferOptions(context.Background(), 4)
+	ctx, options := core.ApplyOptions(ctx)
+	if n := core.GetBufferSize(ctx, 0); n != 4 {
+		t.Fatalf("expected the ctx buffer size kept, got %d", n)
+	}
+	if n := options.Lines(2); n != 2 {
+		t.Fatalf("expected the lines argument kept, got %d", n)
+	}
+}
+
+func TestRun_TypedBufferPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	for v := 1; v <= 5; v++ {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	// the typed policy wins over the blocking buffer set on ctx
+	out := Run(core.WithBufferOptions(ctx, 4), in, Map(func(ctx context.Context, v int) int { return v }), 1,
+		core.WithBuffer(2, core.OverflowDropNewest))
+	waitDrained(t, in)
+	time.Sleep(20 * time.Millisecond)
+
+	if got := core.FromChanMany(ctx, out); len(got) != 2 {
+		t.Fatalf("expected 2 results kept by the typed buffer, got %v", got)
+	}
+}
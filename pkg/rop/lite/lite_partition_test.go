@@ -0,0 +1,75 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func drain[T any](wg *sync.WaitGroup, ch <-chan rop.Result[T], into *[]rop.Result[T]) {
+	defer wg.Done()
+	for r := range ch {
+		*into = append(*into, r)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	for i := 1; i <= 5; i++ {
+		in <- rop.Success(i)
+	}
+	close(in)
+
+	even, odd := Partition(ctx, in, func(ctx context.Context, r rop.Result[int]) bool {
+		return r.Result()%2 == 0
+	})
+
+	var evens, odds []rop.Result[int]
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go drain(wg, even, &evens)
+	go drain(wg, odd, &odds)
+	wg.Wait()
+
+	if len(evens) != 2 || len(odds) != 3 {
+		t.Fatalf("expected 2 even and 3 odd results, got %d and %d", len(evens), len(odds))
+	}
+}
+
+func TestSplitByOutcome(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 4)
+	in <- rop.Success(1)
+	in <- rop.Fail[int](errors.New("bad"))
+	in <- rop.Cancel[int](context.Canceled)
+	in <- rop.Success(2)
+	close(in)
+
+	successCh, failureCh, cancelCh := SplitByOutcome(ctx, in)
+
+	var successes, failures, cancels []rop.Result[int]
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	go drain(wg, successCh, &successes)
+	go drain(wg, failureCh, &failures)
+	go drain(wg, cancelCh, &cancels)
+	wg.Wait()
+
+	if len(successes) != 2 || len(failures) != 1 || len(cancels) != 1 {
+		t.Fatalf("expected 2/1/1 success/failure/cancel, got %d/%d/%d",
+			len(successes), len(failures), len(cancels))
+	}
+}
@@ -0,0 +1,30 @@
+package lite
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/chain"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestStep_RunsChainPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	p := chain.PipeMap(
+		chain.PipeTry(chain.Define[string](), func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) }),
+		func(ctx context.Context, n int) int { return n + 1 })
+
+	sum := 0
+	for r := range Turnout(ctx, core.ToChanManyResults(ctx, []string{"1", "2", "3"}), Step(p.Step()), 2) {
+		sum += r.Result()
+	}
+	if sum != 9 {
+		t.Fatalf("expected sum 9, got %d", sum)
+	}
+}
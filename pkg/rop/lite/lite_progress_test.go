@@ -0,0 +1,98 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+type progressLog struct {
+	mu      sync.Mutex
+	reports []core.Progress
+}
+
+func (l *progressLog) record(ctx context.Context, p core.Progress) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reports = append(l.reports, p)
+}
+
+func (l *progressLog) last() core.Progress {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.reports[len(l.reports)-1]
+}
+
+func (l *progressLog) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.reports)
+}
+
+func TestRun_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	log := &progressLog{}
+	stageCtx := core.WithProgressOptions(ctx, 2, 0, log.record)
+
+	engine := Switch(func(ctx context.Context, v int) rop.Result[int] {
+		if v%3 == 0 {
+			return rop.Fail[int](errors.New("div by 3"))
+		}
+		return rop.Success(v)
+	})
+
+	core.FromChanMany(ctx, Run(stageCtx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4, 5, 6}), engine, 2))
+
+	// every 2 items plus the final report
+	if n := log.count(); n != 4 {
+		t.Fatalf("expected 4 reports, got %d", n)
+	}
+	final := log.last()
+	if !final.Done || final.Processed != 6 || final.Succeeded != 4 || final.Failed != 2 || final.Canceled != 0 {
+		t.Fatalf("unexpected final report %+v", final)
+	}
+}
+
+func TestFinally_ReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	log := &progressLog{}
+	stageCtx := core.WithProgressOptions(ctx, 0, time.Millisecond, log.record)
+
+	in := make(chan rop.Result[int])
+	go func() {
+		defer close(in)
+		for i := 0; i < 3; i++ {
+			in <- rop.Success(i)
+			time.Sleep(5 * time.Millisecond)
+		}
+		in <- rop.Cancel[int](context.Canceled)
+	}()
+
+	core.FromChanMany(ctx, Finally(stageCtx, in, mass.FinallyHandlers[int, string]{
+		OnSuccess: func(ctx context.Context, r int) string { return "ok" },
+		OnError:   func(ctx context.Context, err error) string { return "error" },
+		OnCancel:  func(ctx context.Context, err error) string { return "cancel" },
+	}))
+
+	if n := log.count(); n < 2 {
+		t.Fatalf("expected interval reports before the final one, got %d", n)
+	}
+	final := log.last()
+	if !final.Done || final.Processed != 4 || final.Succeeded != 3 || final.Canceled != 1 {
+		t.Fatalf("unexpected final report %+v", final)
+	}
+}
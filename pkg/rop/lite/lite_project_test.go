@@ -0,0 +1,134 @@
+package lite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type fullRecord struct {
+	ID      int
+	Name    string
+	Payload []byte
+	Tags    map[string]string
+}
+
+type slimRecord struct {
+	ID    int
+	Title string `rop:"Name"`
+	Note  string `rop:"-"`
+}
+
+func TestProjection_ByNameAndTag(t *testing.T) {
+	t.Parallel()
+
+	project, err := Projection[fullRecord, slimRecord]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := project(context.Background(), fullRecord{ID: 7, Name: "seven", Payload: make([]byte, 1024)})
+	if out.ID != 7 || out.Title != "seven" || out.Note != "" {
+		t.Fatalf("unexpected projection: %+v", out)
+	}
+
+	fromPtr, err := Projection[*fullRecord, slimRecord]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fromPtr(context.Background(), &fullRecord{ID: 1, Name: "one"}); got.ID != 1 || got.Title != "one" {
+		t.Fatalf("unexpected projection from pointer: %+v", got)
+	}
+	if got := fromPtr(context.Background(), nil); got.ID != 0 {
+		t.Fatalf("expected zero projection for nil input, got %+v", got)
+	}
+}
+
+func TestProjection_Mismatch(t *testing.T) {
+	t.Parallel()
+
+	type missing struct{ Unknown int }
+	if _, err := Projection[fullRecord, missing](); err == nil {
+		t.Fatalf("expected error for missing source field")
+	}
+
+	type wrongType struct{ ID string }
+	if _, err := Projection[fullRecord, wrongType](); err == nil {
+		t.Fatalf("expected error for non-assignable field")
+	}
+
+	if _, err := Projection[int, slimRecord](); err == nil {
+		t.Fatalf("expected error for non-struct input")
+	}
+}
+
+func TestProject_Stage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	project, err := Projection[fullRecord, slimRecord]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := []fullRecord{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	titles := map[string]bool{}
+	for r := range Turnout(ctx, core.ToChanManyResults(ctx, inputs), Project(project), 2) {
+		titles[r.Result().Title] = true
+	}
+
+	if !titles["a"] || !titles["b"] || len(titles) != 2 {
+		t.Fatalf("unexpected projected titles: %v", titles)
+	}
+}
+
+type Audit struct {
+	Author string
+}
+
+type auditedRecord struct {
+	*Audit
+	ID int
+}
+
+type auditedView struct {
+	*Audit
+	ID int
+}
+
+type flatView struct {
+	ID     int
+	Author string
+}
+
+func TestProjection_EmbeddedPointer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	flat, err := Projection[auditedRecord, flatView]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := flat(ctx, auditedRecord{ID: 1}); got.ID != 1 || got.Author != "" {
+		t.Fatalf("expected a nil embedded pointer read as zero, got %+v", got)
+	}
+	if got := flat(ctx, auditedRecord{Audit: &Audit{Author: "ann"}, ID: 2}); got.Author != "ann" {
+		t.Fatalf("expected the promoted field copied, got %+v", got)
+	}
+
+	nested, err := Projection[flatView, auditedView]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := nested(ctx, flatView{ID: 3, Author: "bob"}); got.Audit == nil || got.Author != "bob" || got.ID != 3 {
+		t.Fatalf("expected the embedded pointer allocated, got %+v", got)
+	}
+	if got := nested(ctx, flatView{ID: 4}); got.Audit != nil {
+		t.Fatalf("expected no allocation for a zero field, got %+v", got.Audit)
+	}
+}
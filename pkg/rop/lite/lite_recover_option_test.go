@@ -0,0 +1,49 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRecoverOptions_PanicBecomesFailure(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []core.ExecutionMode{core.ExecuteAsync, core.ExecuteFused} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		stageCtx := core.WithRecoverOptions(core.WithExecutionOptions(ctx, mode), true)
+
+		engine := Try(func(ctx context.Context, v int) (int, error) {
+			if v == 2 {
+				var m map[string]int
+				m["boom"] = v
+			}
+			return v, nil
+		})
+
+		successes, failures, _, _ := Collect(ctx, Run(stageCtx, core.ToChanManyResults(ctx, []int{1, 2, 3}), engine, 2))
+		cancel()
+
+		var panicErr *rop.PanicError
+		if len(successes) != 2 || len(failures) != 1 || !errors.As(failures[0], &panicErr) || len(panicErr.Stack) == 0 {
+			t.Fatalf("mode %d: expected 2 successes and a panic failure with stack, got %v and %v", mode, successes, failures)
+		}
+	}
+}
+
+func TestValidate_PassesFailuresThrough(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	r := <-Validate(func(ctx context.Context, v int) (bool, string) { return true, "" })(ctx,
+		rop.Fail[int](errors.New("upstream")))
+	if r.IsSuccess() || r.Err().Error() != "upstream" {
+		t.Fatalf("expected the upstream failure to pass through, got: success=%v, err=%v", r.IsSuccess(), r.Err())
+	}
+}
@@ -0,0 +1,87 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRecovered_PanicBecomesFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Recovered(Map(func(ctx context.Context, v int) int {
+		if v == 2 {
+			panic("bad item")
+		}
+		return v * 10
+	}))
+
+	succeeded := 0
+	var panicErr *rop.PanicError
+	for r := range Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3}), engine, 2) {
+		if r.IsSuccess() {
+			succeeded++
+		} else if !errors.As(r.Err(), &panicErr) {
+			t.Fatalf("expected *rop.PanicError, got %v", r.Err())
+		}
+	}
+
+	if succeeded != 2 || panicErr == nil || panicErr.Value != "bad item" || len(panicErr.Stack) == 0 {
+		t.Fatalf("expected 2 successes and a recovered panic with stack, got %d and %v", succeeded, panicErr)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("pipeline stalled until the deadline")
+	}
+}
+
+func TestRecovered_SynchronousPanic(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Recovered(func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		panic(errors.New("engine setup"))
+	})
+
+	r := <-engine(ctx, rop.Success(1))
+	if r.IsSuccess() || r.Err().Error() != "panic: engine setup" {
+		t.Fatalf("expected a panic failure, got: success=%v, err=%v", r.IsSuccess(), r.Err())
+	}
+}
+
+func TestRecovered_EngineGoroutinesStayInGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx, group := core.WithGroup(context.Background())
+	release := make(chan struct{})
+
+	engine := Recovered(func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int], 1)
+		core.Go(ctx, func() {
+			out <- input
+			close(out)
+			<-release
+		})
+		return out
+	})
+
+	if r := <-engine(ctx, rop.Success(1)); !r.IsSuccess() {
+		t.Fatalf("unexpected failure: %v", r.Err())
+	}
+	if err := group.WaitTimeout(20 * time.Millisecond); err == nil {
+		t.Fatalf("expected the engine goroutine to be owned by the pipeline group")
+	}
+
+	close(release)
+	if err := group.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
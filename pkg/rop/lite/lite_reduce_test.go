@@ -0,0 +1,34 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestReduce_SumsSuccesses(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Switch(func(ctx context.Context, r int) rop.Result[int] {
+		if r == 3 {
+			return rop.Fail[int](errors.New("three"))
+		}
+		return rop.Success(r)
+	})
+
+	failed := 0
+	sum := Reduce(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), engine, 2), 0,
+		func(ctx context.Context, acc, v int) int { return acc + v },
+		func(ctx context.Context, r rop.Result[int]) { failed++ })
+
+	if sum != 7 || failed != 1 {
+		t.Fatalf("expected sum 7 and 1 failure, got sum=%d failed=%d", sum, failed)
+	}
+}
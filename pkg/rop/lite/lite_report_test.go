@@ -0,0 +1,55 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestReported_AggregatesPerStage(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	reporter := NewErrorReporter()
+	inputs := make([]int, 30)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	fetch := Reported(reporter, "fetch", Try(func(ctx context.Context, r int) (int, error) {
+		if r%3 == 0 {
+			return 0, errors.New("sql timeout")
+		}
+		return r, nil
+	}))
+	parse := Reported(reporter, "parse", Switch(func(ctx context.Context, r int) rop.Result[int] {
+		if r%5 == 0 {
+			return rop.Fail[int](errors.New("bad record"))
+		}
+		return rop.Success(r)
+	}))
+
+	for range Run(ctx, Run(ctx, core.ToChanManyResults(ctx, inputs), fetch, 3), parse, 3) {
+	}
+
+	summary := reporter.Summary()
+	if len(summary) != 2 {
+		t.Fatalf("expected 2 error groups, got %d: %v", len(summary), summary)
+	}
+	if summary[0].Stage != "fetch" || summary[0].Count != 10 {
+		t.Fatalf("expected 10 fetch errors first, got %v", summary[0])
+	}
+	// 5, 10, 20, 25 fail in parse; 0, 15 already failed in fetch and are not counted again
+	if summary[1].Stage != "parse" || summary[1].Count != 4 {
+		t.Fatalf("expected 4 parse errors, got %v", summary[1])
+	}
+	if summary[0].String() != "sql timeout ×10 in stage fetch" {
+		t.Fatalf("unexpected summary line %q", summary[0].String())
+	}
+}
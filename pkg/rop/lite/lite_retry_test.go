@@ -0,0 +1,68 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+var errTransient = errors.New("transient")
+
+func TestRetry_RecoversAndRecordsAttempts(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	calls := map[int]int{}
+	flaky := Try(func(ctx context.Context, v int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[v]++
+		// v=1 succeeds on its 2nd call, v=2 never does
+		if v == 2 || calls[v] < 2 {
+			return 0, errTransient
+		}
+		return v, nil
+	})
+
+	engine := Retry(flaky, 3, core.ConstantBackoff(time.Millisecond), nil)
+
+	var succeeded int
+	var retryErr *RetryError
+	for r := range Run(ctx, core.ToChanManyResults(ctx, []int{1, 2}), engine, 2) {
+		if r.IsSuccess() {
+			succeeded++
+		} else if !errors.As(r.Err(), &retryErr) {
+			t.Fatalf("expected *RetryError, got %v", r.Err())
+		}
+	}
+
+	if succeeded != 1 || retryErr == nil || retryErr.Attempts != 3 || !errors.Is(retryErr, errTransient) {
+		t.Fatalf("expected 1 success and a RetryError after 3 attempts, got %d and %v", succeeded, retryErr)
+	}
+}
+
+func TestRetry_OnlyRetryable(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	calls := 0
+	permanent := errors.New("permanent")
+	engine := Retry(Try(func(ctx context.Context, v int) (int, error) {
+		calls++
+		return 0, permanent
+	}), 5, nil, func(err error) bool { return errors.Is(err, errTransient) })
+
+	results := core.FromChanMany(ctx, Run(ctx, core.ToChanManyResults(ctx, []int{1}), engine, 1))
+	if len(results) != 1 || !errors.Is(results[0].Err(), permanent) || calls != 1 {
+		t.Fatalf("expected a single attempt with the permanent error, got %d calls and %v", calls, results)
+	}
+}
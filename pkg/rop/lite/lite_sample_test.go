@@ -0,0 +1,45 @@
+package lite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestSampleEvery(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	values := make([]int, 10)
+	for i := range values {
+		values[i] = i
+	}
+
+	kept := core.FromChanMany(ctx, SampleEvery(ctx, core.ToChanManyResults(ctx, values), 3))
+	if len(kept) != 4 || kept[0].Result() != 0 || kept[1].Result() != 3 || kept[3].Result() != 9 {
+		t.Fatalf("expected items 0,3,6,9, got %d items", len(kept))
+	}
+}
+
+func TestSample_Rate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	values := make([]int, 1000)
+	none := core.FromChanMany(ctx, Sample(ctx, core.ToChanManyResults(ctx, values), 0))
+	all := core.FromChanMany(ctx, Sample(ctx, core.ToChanManyResults(ctx, values), 1))
+	some := core.FromChanMany(ctx, Sample(ctx, core.ToChanManyResults(ctx, values), 0.5))
+
+	if len(none) != 0 || len(all) != 1000 {
+		t.Fatalf("expected rate 0 to keep none and rate 1 to keep all, got %d and %d", len(none), len(all))
+	}
+	if len(some) < 350 || len(some) > 650 {
+		t.Fatalf("expected about half the items at rate 0.5, got %d", len(some))
+	}
+}
@@ -0,0 +1,94 @@
+package lite
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestRun_SharedSemaphoreBoundsPipeline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	ctx = core.WithSemaphore(ctx, core.NewSemaphore(2), 1)
+
+	var running, peak atomic.Int32
+	engine := Map(func(ctx context.Context, v int) int {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		running.Add(-1)
+		return v + 1
+	})
+
+	inputs := make([]int, 20)
+	out := Run(ctx, Run(ctx, Run(ctx, core.ToChanManyResults(ctx, inputs), engine, 10), engine, 10), engine, 10)
+
+	results := core.FromChanMany(ctx, out)
+	if len(results) != 20 {
+		t.Fatalf("expected 20 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Result() != 3 {
+			t.Fatalf("expected every item through three stages, got %d", r.Result())
+		}
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("expected at most 2 items running at once, got %d", p)
+	}
+}
+
+func TestRun_SemaphoreKeepsNoIdleLines(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, group := core.WithGroup(core.WithSemaphore(ctx, core.NewSemaphore(4), 1))
+
+	in := make(chan rop.Result[int])
+	engine := Map(func(ctx context.Context, v int) int { return v })
+	out := Run(ctx, Run(ctx, Run(ctx, in, engine, 10), engine, 10), engine, 10)
+
+	time.Sleep(20 * time.Millisecond)
+	// a dispatcher and a closer per stage, no matter how many lines
+	if n := group.Active(); n != 6 {
+		t.Fatalf("expected 6 goroutines for an idle 3-stage pipeline, got %d", n)
+	}
+
+	close(in)
+	for range out {
+	}
+	if err := group.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSemaphore_AcquireCanceled(t *testing.T) {
+	t.Parallel()
+
+	sem := core.NewSemaphore(1)
+	if err := sem.Acquire(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx, 1); err == nil {
+		t.Fatal("expected acquire on a full semaphore to end with ctx")
+	}
+
+	sem.Release(1)
+	if err := sem.Acquire(context.Background(), 1); err != nil {
+		t.Fatalf("expected the released token to be free, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package lite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Map(func(ctx context.Context, v int) int { return v * v })
+	unordered := Run(ctx, core.ToChanManyResults(ctx, []int{5, 3, 1, 4, 2}), engine, 3)
+
+	sorted := core.FromChanMany(ctx, SortBy(ctx, unordered, func(a, b rop.Result[int]) bool {
+		return a.Result() < b.Result()
+	}))
+
+	want := []int{1, 4, 9, 16, 25}
+	if len(sorted) != len(want) {
+		t.Fatalf("expected %d results, got %d", len(want), len(sorted))
+	}
+	for i, r := range sorted {
+		if r.Result() != want[i] {
+			t.Fatalf("position %d: expected %d, got %d", i, want[i], r.Result())
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPipe_PlainFunctions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results := Pipe(ctx, []int{1, 2, 3, 4},
+		TryStage(func(ctx context.Context, v int) (int, error) {
+			if v == 3 {
+				return 0, errors.New("three")
+			}
+			return v, nil
+		}, 2),
+		MapStage(func(ctx context.Context, v int) int { return v * 10 }, 2))
+
+	var values []int
+	failed := 0
+	for _, r := range results {
+		if r.IsSuccess() {
+			values = append(values, r.Result())
+		} else {
+			failed++
+		}
+	}
+	sort.Ints(values)
+
+	if len(values) != 3 || values[0] != 10 || values[1] != 20 || values[2] != 40 || failed != 1 {
+		t.Fatalf("expected [10 20 40] and 1 failure, got %v and %d", values, failed)
+	}
+}
@@ -0,0 +1,57 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func slowTry(ctx context.Context, d int) (int, error) {
+	select {
+	case <-time.After(time.Duration(d) * time.Millisecond):
+		return d, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func TestStageTimeout(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []core.ExecutionMode{core.ExecuteAsync, core.ExecuteFused} {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+
+		stageCtx := core.WithStageTimeout(core.WithExecutionOptions(ctx, mode), 30*time.Millisecond)
+		successes, _, cancels, _ := Collect(ctx,
+			Run(stageCtx, core.ToChanManyResults(ctx, []int{1, 500, 2}), Try(slowTry), 3))
+		cancel()
+
+		if len(successes) != 2 || len(cancels) != 1 || !errors.Is(cancels[0], context.DeadlineExceeded) {
+			t.Fatalf("mode %d: expected 2 successes and 1 expired item, got %v and %v", mode, successes, cancels)
+		}
+	}
+}
+
+func TestStageTimeout_IgnoringContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// a Map that never looks at ctx is still let go once its deadline passes
+	stuck := Map(func(ctx context.Context, v int) int {
+		time.Sleep(300 * time.Millisecond)
+		return v
+	})
+
+	start := time.Now()
+	r := <-stuck(core.WithStageTimeout(ctx, 20*time.Millisecond), rop.Success(1))
+
+	if !r.IsCancel() || time.Since(start) > 200*time.Millisecond {
+		t.Fatalf("expected an early cancel result, got cancel=%v after %v", r.IsCancel(), time.Since(start))
+	}
+}
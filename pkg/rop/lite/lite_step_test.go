@@ -0,0 +1,36 @@
+package lite
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/solo"
+)
+
+func TestStep_FusedEngine(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	engine := Step(solo.Compose2(
+		solo.TryStep(func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) }),
+		solo.MapStep(func(ctx context.Context, n int) int { return n * n }),
+	))
+
+	sum, failed := 0, 0
+	for r := range Turnout(ctx, core.ToChanManyResults(ctx, []string{"1", "2", "x", "3"}), engine, 2) {
+		if r.IsSuccess() {
+			sum += r.Result()
+		} else {
+			failed++
+		}
+	}
+
+	if sum != 14 || failed != 1 {
+		t.Fatalf("expected sum 14 and 1 failure, got sum=%d failed=%d", sum, failed)
+	}
+}
@@ -0,0 +1,42 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestWithTimeout_PerItem(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	engine := WithTimeout(Try(func(ctx context.Context, d int) (int, error) {
+		select {
+		case <-time.After(time.Duration(d) * time.Millisecond):
+			return d, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}), 50*time.Millisecond)
+
+	succeeded, canceled := 0, 0
+	for r := range Run(ctx, core.ToChanManyResults(ctx, []int{1, 500, 2, 3}), engine, 2) {
+		switch {
+		case r.IsSuccess():
+			succeeded++
+		case r.IsCancel() && errors.Is(r.Err(), context.DeadlineExceeded):
+			canceled++
+		default:
+			t.Fatalf("unexpected result: %v", r.Err())
+		}
+	}
+
+	if succeeded != 3 || canceled != 1 {
+		t.Fatalf("expected 3 successes and 1 timed out item, got %d and %d", succeeded, canceled)
+	}
+}
@@ -0,0 +1,100 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+var trailerHandlers = mass.FinallyHandlers[int, int]{
+	OnSuccess: func(ctx context.Context, r int) int { return r },
+	OnError:   func(ctx context.Context, err error) int { return -1 },
+	OnCancel:  func(ctx context.Context, err error) int { return -2 },
+}
+
+func TestFinallyVerified_CompleteStream(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	source, trailer := core.WithTrailer(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), nil)
+	out, errCh := FinallyVerified(ctx,
+		Run(ctx, source, Map(func(ctx context.Context, r int) int { return r * 2 }), 2),
+		trailerHandlers, trailer)
+
+	count := 0
+	for range out {
+		count++
+	}
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("expected intact stream, got %v", err)
+	}
+	if count != 4 {
+		t.Fatalf("expected 4 values, got %d", count)
+	}
+}
+
+func TestFinallyVerified_DetectsTruncation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	source, trailer := core.WithTrailer(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3, 4}), nil)
+
+	// a faulty stage that silently drops even values (and stops its line)
+	dropping := func(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+		out := make(chan rop.Result[int], 1)
+		if input.Result()%2 != 0 {
+			out <- input
+		}
+		close(out)
+		return out
+	}
+
+	out, errCh := FinallyVerified(ctx, Run(ctx, source, dropping, 4), trailerHandlers, trailer)
+	for range out {
+	}
+
+	err := <-errCh
+	var truncated *core.TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("expected truncation error, got %v", err)
+	}
+	if truncated.Expected.Count != 4 {
+		t.Fatalf("expected trailer count 4, got %d", truncated.Expected.Count)
+	}
+}
+
+func TestVerifyTrailer_Checksum(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sum := func(v int) uint64 { return uint64(v) }
+
+	source, trailer := core.WithTrailer(ctx, core.ToChanMany(ctx, []int{1, 2, 3}), sum)
+
+	altered := make(chan int)
+	go func() {
+		defer close(altered)
+		for v := range source {
+			altered <- v + 1
+		}
+	}()
+
+	out, errCh := core.VerifyTrailer(ctx, altered, trailer, sum)
+	for range out {
+	}
+
+	var checksumErr *core.ChecksumError
+	if err := <-errCh; !errors.As(err, &checksumErr) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+}
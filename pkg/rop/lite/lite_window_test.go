@@ -0,0 +1,62 @@
+package lite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func sumWindow(ctx context.Context, values []int) rop.Result[int] {
+	sum := 0
+	for _, v := range values {
+		sum += v
+	}
+	return rop.Success(sum)
+}
+
+func TestWindow_OneResultPerWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int])
+	go func() {
+		defer close(in)
+		in <- rop.Success(1)
+		in <- rop.Success(2)
+		time.Sleep(150 * time.Millisecond)
+		in <- rop.Success(10)
+	}()
+
+	var sums []int
+	for r := range Window(ctx, in, 100*time.Millisecond, sumWindow) {
+		sums = append(sums, r.Result())
+	}
+
+	if len(sums) != 2 || sums[0] != 3 || sums[1] != 10 {
+		t.Fatalf("expected window sums [3 10], got %v", sums)
+	}
+}
+
+func TestWindow_FlushesPartialWindowOnClose(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 3)
+	in <- rop.Success(4)
+	in <- rop.Success(5)
+	close(in)
+
+	var sums []int
+	for r := range Window(ctx, in, time.Minute, sumWindow) {
+		sums = append(sums, r.Result())
+	}
+	if len(sums) != 1 || sums[0] != 9 {
+		t.Fatalf("expected a single partial window sum 9, got %v", sums)
+	}
+}
@@ -0,0 +1,51 @@
+package lite
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestZipCh(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	a := make(chan rop.Result[int], 3)
+	a <- rop.Success(1)
+	a <- rop.Fail[int](errors.New("a failed"))
+	a <- rop.Success(3)
+	close(a)
+
+	b := make(chan rop.Result[string], 4)
+	b <- rop.Success("x")
+	b <- rop.Fail[string](errors.New("b failed"))
+	b <- rop.Cancel[string](context.Canceled)
+	b <- rop.Success("unpaired")
+	close(b)
+
+	var results []rop.Result[string]
+	for r := range ZipCh(ctx, a, b, func(ctx context.Context, n int, s string) rop.Result[string] {
+		return rop.Success(strconv.Itoa(n) + s)
+	}) {
+		results = append(results, r)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 pairs, got %d", len(results))
+	}
+	if !results[0].IsSuccess() || results[0].Result() != "1x" {
+		t.Fatalf("expected 1x, got: success=%v, val=%v", results[0].IsSuccess(), results[0].Result())
+	}
+	if results[1].IsSuccess() || results[1].IsCancel() || results[1].Err().Error() != "a failed\nb failed" {
+		t.Fatalf("expected joined failure, got: cancel=%v, err=%v", results[1].IsCancel(), results[1].Err())
+	}
+	if !results[2].IsCancel() {
+		t.Fatalf("expected the canceled side to cancel the pair, got err=%v", results[2].Err())
+	}
+}
@@ -0,0 +1,30 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Named runs engine as the stage name: failures and cancels it produces carry
+// a *core.StageError naming it, and the name is available to the engine (and
+// to anything it reports to) through core.GetStageName.
+func Named[In, Out any](name string, engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out]) func(
+	ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		stageCtx := core.WithStageName(ctx, name)
+		out := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			if r, ok := <-engine(stageCtx, input); ok {
+				out <- core.AttributeStage(stageCtx, input, r)
+			}
+		})
+
+		return out
+	}
+}
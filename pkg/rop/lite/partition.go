@@ -0,0 +1,78 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Partition splits input into results matching pred and the rest.
+// Both returned channels must be drained, or the split blocks.
+func Partition[T any](ctx context.Context, input <-chan rop.Result[T],
+	pred func(ctx context.Context, r rop.Result[T]) bool) (match, rest <-chan rop.Result[T]) {
+
+	matchCh := make(chan rop.Result[T])
+	restCh := make(chan rop.Result[T])
+
+	split(ctx, input, func(r rop.Result[T]) chan<- rop.Result[T] {
+		if pred(ctx, r) {
+			return matchCh
+		}
+		return restCh
+	}, matchCh, restCh)
+
+	return matchCh, restCh
+}
+
+// SplitByOutcome splits input into success, failure and cancel channels.
+// All three returned channels must be drained, or the split blocks.
+func SplitByOutcome[T any](ctx context.Context, input <-chan rop.Result[T]) (success, failure,
+	cancel <-chan rop.Result[T]) {
+
+	successCh := make(chan rop.Result[T])
+	failureCh := make(chan rop.Result[T])
+	cancelCh := make(chan rop.Result[T])
+
+	split(ctx, input, func(r rop.Result[T]) chan<- rop.Result[T] {
+		switch {
+		case r.IsSuccess():
+			return successCh
+		case r.IsCancel():
+			return cancelCh
+		default:
+			return failureCh
+		}
+	}, successCh, failureCh, cancelCh)
+
+	return successCh, failureCh, cancelCh
+}
+
+func split[T any](ctx context.Context, input <-chan rop.Result[T],
+	route func(r rop.Result[T]) chan<- rop.Result[T], outs ...chan rop.Result[T]) {
+
+	core.Go(ctx, func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-input:
+				if !ok {
+					return
+				}
+
+				select {
+				case route(r) <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package lite
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+)
+
+const ProjectTag = "rop"
+
+func Project[In, Out any](project func(ctx context.Context, r In) Out) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		return mass.Mapping(ctx, input, project, nil)
+	}
+}
+
+// Projection builds a projection from struct In (or *In) to struct Out by copying
+// every exported Out field from the In field with the same name, or with the
+// name given in the `rop:"Field"` tag. Fields tagged `rop:"-"` are left zero.
+// Fields promoted through embedded pointers are read as zero when the In
+// pointer is nil and allocate the Out pointer when set; those behind an
+// unexported embedded pointer of Out cannot be set and are left zero.
+// The field mapping is resolved once, so mismatches are reported up front.
+func Projection[In, Out any]() (func(ctx context.Context, r In) Out, error) {
+	inType := reflect.TypeFor[In]()
+	outType := reflect.TypeFor[Out]()
+
+	isPtr := inType.Kind() == reflect.Pointer
+	if isPtr {
+		inType = inType.Elem()
+	}
+	if inType.Kind() != reflect.Struct || outType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("projection: %v -> %v: both types must be structs", inType, outType)
+	}
+
+	type fieldPair struct{ from, to []int }
+	pairs := make([]fieldPair, 0, outType.NumField())
+
+	for _, outField := range reflect.VisibleFields(outType) {
+		if !outField.IsExported() || outField.Anonymous || behindUnexportedPointer(outType, outField.Index) {
+			continue
+		}
+
+		name := outField.Name
+		if tag, ok := outField.Tag.Lookup(ProjectTag); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+
+		inField, ok := inType.FieldByName(name)
+		if !ok || !inField.IsExported() {
+			return nil, fmt.Errorf("projection: %v has no exported field %q for %v.%s",
+				inType, name, outType, outField.Name)
+		}
+		if !inField.Type.AssignableTo(outField.Type) {
+			return nil, fmt.Errorf("projection: %v.%s (%v) is not assignable to %v.%s (%v)",
+				inType, inField.Name, inField.Type, outType, outField.Name, outField.Type)
+		}
+		pairs = append(pairs, fieldPair{from: inField.Index, to: outField.Index})
+	}
+
+	return func(_ context.Context, r In) Out {
+		var out Out
+
+		in := reflect.ValueOf(&r).Elem()
+		if isPtr {
+			if in.IsNil() {
+				return out
+			}
+			in = in.Elem()
+		}
+
+		dst := reflect.ValueOf(&out).Elem()
+		for _, p := range pairs {
+			v, err := in.FieldByIndexErr(p.from)
+			if err != nil || v.IsZero() {
+				// a nil embedded pointer on the way, or nothing to copy
+				continue
+			}
+			settableField(dst, p.to).Set(v)
+		}
+		return out
+	}, nil
+}
+
+// behindUnexportedPointer reports whether the field at index is promoted
+// through an unexported embedded pointer, which reflection cannot allocate.
+func behindUnexportedPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		f := t.Field(i)
+		if f.Type.Kind() == reflect.Pointer {
+			if !f.IsExported() {
+				return true
+			}
+			t = f.Type.Elem()
+		} else {
+			t = f.Type
+		}
+	}
+	return false
+}
+
+// settableField is v.FieldByIndex(index) allocating nil embedded pointers.
+func settableField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
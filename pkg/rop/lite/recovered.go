@@ -0,0 +1,66 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Recovered wraps engine so that a panic in it, or in any goroutine it starts
+// through core.Go, is emitted as a failure carrying a *rop.PanicError (value
+// and stack) instead of crashing or stalling the pipeline.
+func Recovered[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out]) func(
+	ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			panicked := make(chan *rop.PanicError, 1)
+			onPanic := func(p *rop.PanicError) {
+				select {
+				case panicked <- p:
+				default:
+				}
+			}
+
+			// the engine's goroutines get a group of their own (still owned by the
+			// group of ctx), so that once its channel closes we can wait for a
+			// panicking goroutine to finish reporting
+			engineCtx, group := core.WithGroup(core.WithPanicHandler(ctx, onPanic))
+
+			results, ok := startEngine(engineCtx, input, engine, onPanic)
+			if ok {
+				if r, emitted := <-results; emitted {
+					out <- r
+					return
+				}
+				group.Wait()
+			}
+
+			select {
+			case p := <-panicked:
+				out <- rop.Fail[Out](p)
+			default:
+			}
+		})
+
+		return out
+	}
+}
+
+func startEngine[In, Out any](ctx context.Context, input rop.Result[In],
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	onPanic func(p *rop.PanicError)) (results <-chan rop.Result[Out], ok bool) {
+
+	defer func() {
+		if v := recover(); v != nil {
+			onPanic(rop.NewPanicError(v))
+			results, ok = nil, false
+		}
+	}()
+	return engine(ctx, input), true
+}
@@ -0,0 +1,35 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Reduce folds every successful result of input into seed using step and
+// returns the accumulator once input closes (or ctx is done). Failed and
+// canceled results are handed to onErr, if set, and otherwise skipped.
+func Reduce[T, Acc any](ctx context.Context, input <-chan rop.Result[T], seed Acc,
+	step func(ctx context.Context, acc Acc, v T) Acc,
+	onErr func(ctx context.Context, r rop.Result[T])) Acc {
+
+	acc := seed
+	for {
+		select {
+		case <-ctx.Done():
+			return acc
+		case r, ok := <-input:
+			if !ok {
+				return acc
+			}
+
+			if !r.IsSuccess() {
+				if onErr != nil {
+					onErr(ctx, r)
+				}
+				continue
+			}
+			acc = step(ctx, acc, r.Result())
+		}
+	}
+}
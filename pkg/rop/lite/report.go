@@ -0,0 +1,108 @@
+package lite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type ErrorGroup struct {
+	Stage   string
+	Message string
+	Count   int
+	// Err is the first error observed for the group
+	Err error
+}
+
+func (g ErrorGroup) String() string {
+	return fmt.Sprintf("%s ×%d in stage %s", g.Message, g.Count, g.Stage)
+}
+
+type errorGroupKey struct {
+	stage   string
+	message string
+}
+
+// ErrorReporter aggregates identical errors (by stage and message) produced
+// across items and stages of a run into a compact summary.
+type ErrorReporter struct {
+	mu     sync.Mutex
+	groups map[errorGroupKey]*ErrorGroup
+	order  []errorGroupKey
+}
+
+func NewErrorReporter() *ErrorReporter {
+	return &ErrorReporter{groups: make(map[errorGroupKey]*ErrorGroup)}
+}
+
+func (r *ErrorReporter) Record(stage string, err error) {
+	if err == nil {
+		return
+	}
+
+	key := errorGroupKey{stage: stage, message: err.Error()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	g, ok := r.groups[key]
+	if !ok {
+		g = &ErrorGroup{Stage: stage, Message: key.message, Err: err}
+		r.groups[key] = g
+		r.order = append(r.order, key)
+	}
+	g.Count++
+}
+
+// Summary returns the error groups, most frequent first (ties keep first-seen order).
+func (r *ErrorReporter) Summary() []ErrorGroup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]ErrorGroup, 0, len(r.order))
+	for _, key := range r.order {
+		res = append(res, *r.groups[key])
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Count > res[j].Count })
+	return res
+}
+
+func (r *ErrorReporter) String() string {
+	lines := make([]string, 0)
+	for _, g := range r.Summary() {
+		lines = append(lines, g.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reported wraps engine so failures it produces from successful inputs are
+// recorded under stage. Failures passed through from earlier stages are not
+// counted again; cancels are not errors and are ignored.
+func Reported[In, Out any](reporter *ErrorReporter, stage string,
+	engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out]) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		ch := engine(ctx, input)
+		if !input.IsSuccess() {
+			return ch
+		}
+
+		out := make(chan rop.Result[Out])
+		core.Go(ctx, func() {
+			defer close(out)
+
+			for r := range ch {
+				if r.IsFailure() && !r.IsCancel() {
+					reporter.Record(stage, r.Err())
+				}
+				out <- r
+			}
+		})
+		return out
+	}
+}
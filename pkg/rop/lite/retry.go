@@ -0,0 +1,58 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// RetryError is the final failure of an item that was retried by Retry.
+type RetryError = core.RetryError
+
+// Retry re-feeds an item through engine, up to attempts times in total, while
+// it fails with an error accepted by retryIf (nil retries every failure),
+// waiting backoff(attempt) in between. A failure that was retried is emitted
+// as a *RetryError recording the number of attempts. Cancels are never retried.
+func Retry[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	attempts int, backoff core.Backoff, retryIf func(err error) bool) func(ctx context.Context,
+	input rop.Result[In]) <-chan rop.Result[Out] {
+
+	if backoff == nil {
+		backoff = core.ConstantBackoff(0)
+	}
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		if !input.IsSuccess() {
+			return engine(ctx, input)
+		}
+
+		out := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			for attempt := 1; ; attempt++ {
+				r, ok := <-engine(ctx, input)
+				if !ok {
+					return
+				}
+
+				if r.IsSuccess() || r.IsCancel() || (retryIf != nil && !retryIf(r.Err())) || attempt >= attempts {
+					if attempt > 1 && !r.IsSuccess() && !r.IsCancel() {
+						r = rop.Fail[Out](&core.RetryError{Attempts: attempt, Err: r.Err()})
+					}
+					out <- r
+					return
+				}
+
+				if err := core.Sleep(ctx, backoff(attempt)); err != nil {
+					out <- rop.Cancel[Out](err)
+					return
+				}
+			}
+		})
+
+		return out
+	}
+}
@@ -0,0 +1,56 @@
+package lite
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Sample passes on roughly rate (0..1) of the results of input at random and
+// skips the rest.
+func Sample[T any](ctx context.Context, input <-chan rop.Result[T], rate float64) <-chan rop.Result[T] {
+	return sampled(ctx, input, func(int) bool {
+		return rand.Float64() < rate
+	})
+}
+
+// SampleEvery passes on every n-th result of input (the 1st, n+1-th, ...) and skips the rest.
+func SampleEvery[T any](ctx context.Context, input <-chan rop.Result[T], n int) <-chan rop.Result[T] {
+	return sampled(ctx, input, func(i int) bool {
+		return n <= 1 || i%n == 0
+	})
+}
+
+func sampled[T any](ctx context.Context, input <-chan rop.Result[T],
+	keep func(i int) bool) <-chan rop.Result[T] {
+
+	out := make(chan rop.Result[T], core.GetBufferSize(ctx, 0))
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-input:
+				if !ok {
+					return
+				}
+				if !keep(i) {
+					continue
+				}
+
+				select {
+				case out <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return out
+}
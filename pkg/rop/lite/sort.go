@@ -0,0 +1,39 @@
+package lite
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// SortBy drains input and then emits its results ordered by less (stable).
+// It holds every result in memory, so it is meant for bounded batches.
+func SortBy[T any](ctx context.Context, input <-chan rop.Result[T],
+	less func(a, b rop.Result[T]) bool) <-chan rop.Result[T] {
+
+	out := make(chan rop.Result[T], core.GetBufferSize(ctx, 0))
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		results := core.FromChanMany(ctx, input)
+		if ctx.Err() != nil {
+			return
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return less(results[i], results[j])
+		})
+
+		for _, r := range results {
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return out
+}
@@ -0,0 +1,39 @@
+package lite
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Stage is a whole pipeline step: an engine already bound to its input channel and lines.
+type Stage[In, Out any] func(ctx context.Context, inputCh <-chan rop.Result[In]) <-chan rop.Result[Out]
+
+// StageOf binds engine to lines as a Stage (Turnout).
+func StageOf[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	lines int) Stage[In, Out] {
+	return func(ctx context.Context, inputCh <-chan rop.Result[In]) <-chan rop.Result[Out] {
+		return Turnout(ctx, inputCh, engine, lines)
+	}
+}
+
+// TryStage runs a plain (Out, error) function over lines.
+func TryStage[In, Out any](f func(ctx context.Context, r In) (Out, error), lines int) Stage[In, Out] {
+	return StageOf(Try(f), lines)
+}
+
+// MapStage runs a plain In -> Out function over lines.
+func MapStage[In, Out any](f func(ctx context.Context, r In) Out, lines int) Stage[In, Out] {
+	return StageOf(Map(f), lines)
+}
+
+// Pipe feeds inputs through stages in order and returns every final result.
+// Results arrive in completion order, which is not the input order when lines > 1.
+func Pipe[T any](ctx context.Context, inputs []T, stages ...Stage[T, T]) []rop.Result[T] {
+	ch := core.ToChanManyResults(ctx, inputs)
+	for _, stage := range stages {
+		ch = stage(ctx, ch)
+	}
+	return core.FromChanMany(ctx, ch)
+}
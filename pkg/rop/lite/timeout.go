@@ -0,0 +1,38 @@
+package lite
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// WithTimeout gives every item its own deadline of d. If engine does not
+// produce a result in time, a cancel result carrying the deadline error is
+// emitted for that item instead, and the rest of the run goes on.
+func WithTimeout[In, Out any](engine func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	d time.Duration) func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		out := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			itemCtx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+
+			select {
+			case r, ok := <-engine(itemCtx, input):
+				if ok {
+					out <- r
+				}
+			case <-itemCtx.Done():
+				out <- rop.Cancel[Out](context.Cause(itemCtx))
+			}
+		})
+
+		return out
+	}
+}
@@ -0,0 +1,72 @@
+package lite
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Window collects successful values into tumbling windows of length d and
+// emits aggregate's result once per window. Windows without values are skipped,
+// and the last (partial) window is aggregated when input closes.
+// Failures and cancels are passed on individually.
+func Window[T, U any](ctx context.Context, input <-chan rop.Result[T], d time.Duration,
+	aggregate func(ctx context.Context, values []T) rop.Result[U]) <-chan rop.Result[U] {
+
+	out := make(chan rop.Result[U], core.GetBufferSize(ctx, 0))
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+
+		var window []T
+
+		send := func(r rop.Result[U]) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		flush := func() bool {
+			if len(window) == 0 {
+				return true
+			}
+			values := window
+			window = nil
+			return send(aggregate(ctx, values))
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !flush() {
+					return
+				}
+			case r, ok := <-input:
+				if !ok {
+					flush()
+					return
+				}
+
+				if !r.IsSuccess() {
+					if !send(passOn[T, U](r)) {
+						return
+					}
+					continue
+				}
+				window = append(window, r.Result())
+			}
+		}
+	})
+
+	return out
+}
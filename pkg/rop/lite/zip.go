@@ -0,0 +1,83 @@
+package lite
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// ZipCh pairs the results of a and b in order and emits combine's result for
+// each pair, stopping when either channel closes. A pair with an off-track side
+// is not combined: a cancel takes precedence over a failure and the errors of
+// both sides are joined (a first).
+func ZipCh[A, B, C any](ctx context.Context, a <-chan rop.Result[A], b <-chan rop.Result[B],
+	combine func(ctx context.Context, a A, b B) rop.Result[C]) <-chan rop.Result[C] {
+
+	out := make(chan rop.Result[C], core.GetBufferSize(ctx, 0))
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		for {
+			var ra rop.Result[A]
+			var rb rop.Result[B]
+			var ok bool
+
+			select {
+			case <-ctx.Done():
+				return
+			case ra, ok = <-a:
+				if !ok {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case rb, ok = <-b:
+				if !ok {
+					return
+				}
+			}
+
+			select {
+			case out <- zipPair(ctx, ra, rb, combine):
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return out
+}
+
+func zipPair[A, B, C any](ctx context.Context, ra rop.Result[A], rb rop.Result[B],
+	combine func(ctx context.Context, a A, b B) rop.Result[C]) rop.Result[C] {
+
+	if ra.IsSuccess() && rb.IsSuccess() {
+		return combine(ctx, ra.Result(), rb.Result())
+	}
+
+	var errs []error
+	canceled := false
+	if !ra.IsSuccess() {
+		errs = append(errs, ra.Err())
+		canceled = ra.IsCancel()
+	}
+	if !rb.IsSuccess() {
+		errs = append(errs, rb.Err())
+		canceled = canceled || rb.IsCancel()
+	}
+
+	err := errs[0]
+	if len(errs) > 1 {
+		err = errors.Join(errs...)
+	}
+	if canceled {
+		return rop.Cancel[C](err)
+	}
+	return rop.Fail[C](err)
+}
@@ -0,0 +1,125 @@
+package mass
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type BatchingCancelHandlers[T any] struct {
+	// OnCancelBatch receives the partial batch collected when ctx is done
+	OnCancelBatch func(ctx context.Context, batch []T, outCh chan<- rop.Result[[]T])
+	// OnCancel receives the rest of the input when ctx is done
+	OnCancel func(ctx context.Context, inputCh <-chan rop.Result[T], outCh chan<- rop.Result[[]T])
+}
+
+// Batching groups successful results into batches of up to size values. A batch
+// is flushed when it is full, when maxWait has passed since its first value,
+// or when inputCh closes. Failures and cancels are passed on individually.
+// A size <= 0 disables the size limit; a maxWait <= 0 disables the timer.
+// When ctx is done the partial batch and the remaining input go to cancelHandlers
+// (and are dropped without them).
+func Batching[T any](ctx context.Context, inputCh <-chan rop.Result[T], size int, maxWait time.Duration,
+	cancelHandlers BatchingCancelHandlers[T]) <-chan rop.Result[[]T] {
+
+	out := make(chan rop.Result[[]T], core.GetBufferSize(ctx, 0))
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		stopTimer := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+		}
+		defer stopTimer()
+
+		onCancel := func() {
+			if cancelHandlers.OnCancelBatch != nil && len(batch) > 0 {
+				cancelHandlers.OnCancelBatch(ctx, batch, out)
+			}
+			if cancelHandlers.OnCancel != nil {
+				cancelHandlers.OnCancel(ctx, inputCh, out)
+			}
+		}
+
+		send := func(r rop.Result[[]T]) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		flush := func() bool {
+			stopTimer()
+			if len(batch) == 0 {
+				return true
+			}
+			if !send(rop.Success(batch)) {
+				// batch is still held for OnCancelBatch
+				return false
+			}
+			batch = nil
+			return true
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				onCancel()
+				return
+			case <-timeout:
+				if !flush() {
+					onCancel()
+					return
+				}
+			case r, ok := <-inputCh:
+				if !ok {
+					if !flush() {
+						onCancel()
+					}
+					return
+				}
+
+				if !r.IsSuccess() {
+					if !send(passOn[T, []T](r)) {
+						onCancel()
+						return
+					}
+					continue
+				}
+
+				batch = append(batch, r.Result())
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if size > 0 && len(batch) >= size {
+					if !flush() {
+						onCancel()
+						return
+					}
+				}
+			}
+		}
+	})
+
+	return out
+}
+
+// FlushRemainingBatch emits a partial batch as a success on cancel, when
+// processing of remaining values is enabled (see core.WithProcessOptions).
+func FlushRemainingBatch[T any](ctx context.Context, batch []T, outCh chan<- rop.Result[[]T]) {
+	if core.IsProcessRemainingEnabled(ctx, true) {
+		outCh <- rop.Success(batch)
+	}
+}
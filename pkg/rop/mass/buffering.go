@@ -0,0 +1,110 @@
+package mass
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+var ErrBufferFull = errors.New("buffer full: result dropped")
+
+// queued is a result waiting in a Buffering queue, or with failures > 0 a
+// run of that many ErrBufferFull failures.
+type queued[T any] struct {
+	r        rop.Result[T]
+	failures int
+}
+
+// Buffering puts a buffer of size results between inputCh and its consumer and
+// applies policy when it is full. Unlike a buffered channel it keeps reading
+// inputCh under the drop and fail policies, so a slow consumer never holds
+// the producer back. With OverflowFail, the failures standing in for dropped
+// results are kept as counts, so they take no room in the buffer. A cancel of
+// ctx does not stop it: it still passes on what upstream cancel handlers emit
+// until inputCh is closed.
+func Buffering[T any](ctx context.Context, inputCh <-chan rop.Result[T], size int,
+	policy core.OverflowPolicy) <-chan rop.Result[T] {
+
+	if size < 1 {
+		size = 1
+	}
+	out := make(chan rop.Result[T])
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		var queue []queued[T]
+		// results in queue, not counting the failure runs
+		held := 0
+		in := inputCh
+		for in != nil || len(queue) > 0 {
+			// with OverflowBlock a full buffer stops reading, holding the producer back
+			receive := in
+			if policy == core.OverflowBlock && held >= size {
+				receive = nil
+			}
+
+			var send chan<- rop.Result[T]
+			var next rop.Result[T]
+			if len(queue) > 0 {
+				send, next = out, queue[0].r
+				if queue[0].failures > 0 {
+					next = rop.Fail[T](ErrBufferFull)
+				}
+			}
+
+			select {
+			case send <- next:
+				if queue[0].failures > 1 {
+					queue[0].failures--
+					continue
+				}
+				if queue[0].failures == 0 {
+					held--
+				}
+				queue = queue[1:]
+			case r, ok := <-receive:
+				if !ok {
+					in = nil
+					continue
+				}
+				if held < size {
+					queue = append(queue, queued[T]{r: r})
+					held++
+					continue
+				}
+
+				switch policy {
+				case core.OverflowDropOldest:
+					// only OverflowFail queues failure runs, so the head is a result
+					queue = append(queue[1:], queued[T]{r: r})
+				case core.OverflowFail:
+					if last := len(queue) - 1; queue[last].failures > 0 {
+						queue[last].failures++
+					} else {
+						queue = append(queue, queued[T]{failures: 1})
+					}
+				}
+			}
+		}
+	})
+
+	return out
+}
+
+// Buffered applies the buffer policy of ctx (core.WithBufferPolicy) to a
+// stage output created with core.BufferOptions.ChannelSize. Blocking buffers
+// are plain buffered channels, so out is returned as it is for them.
+func Buffered[T any](ctx context.Context, out <-chan rop.Result[T]) <-chan rop.Result[T] {
+	return BufferedWith(ctx, out, core.GetBufferOptions(ctx))
+}
+
+// BufferedWith is Buffered with the buffer options given rather than read from ctx.
+func BufferedWith[T any](ctx context.Context, out <-chan rop.Result[T], options core.BufferOptions) <-chan rop.Result[T] {
+	if options.Policy == core.OverflowBlock {
+		return out
+	}
+	return Buffering(ctx, out, options.Size, options.Policy)
+}
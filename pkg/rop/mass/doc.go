@@ -4,4 +4,9 @@
 //
 // It is typically used by higher-level packages (lite/custom) to compose
 // concurrent pipelines, integrating cancellation handlers and select loops.
+//
+// Per-item stages run in a goroutine pair by default; a context carrying
+// core.WithExecutionOptions(ctx, core.ExecuteFused) runs them inline instead.
+// Buffering puts a bounded buffer with an explicit overflow policy between stages.
+// FinalizingOrdered finalizes with several workers while keeping input order.
 package mass
\ No newline at end of file
@@ -0,0 +1,26 @@
+package mass
+
+import "context"
+
+// DefaultFinallyHandlers maps successes with onSuccess and both failures and
+// cancels to the zero Out.
+func DefaultFinallyHandlers[In, Out any](onSuccess func(ctx context.Context, r In) Out) FinallyHandlers[In, Out] {
+	return FinallyHandlers[In, Out]{
+		OnSuccess: onSuccess,
+		OnError:   zeroOnError[Out],
+		OnCancel:  zeroOnError[Out],
+	}
+}
+
+// IdentityFinallyHandlers passes successful values through unchanged and maps
+// failures and cancels to the zero T.
+func IdentityFinallyHandlers[T any]() FinallyHandlers[T, T] {
+	return DefaultFinallyHandlers(func(ctx context.Context, r T) T {
+		return r
+	})
+}
+
+func zeroOnError[Out any](context.Context, error) Out {
+	var zero Out
+	return zero
+}
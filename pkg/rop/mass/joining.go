@@ -0,0 +1,138 @@
+package mass
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type joinEntry[T any] struct {
+	value T
+	at    time.Time
+}
+
+// Joining joins the successes of left and right whose keys match, emitting
+// combine's result for every pair that arrived within window of each other
+// (window <= 0 keeps every item until both inputs close). Failures and cancels
+// of either side are passed on individually. It ends when both inputs close.
+func Joining[L, R, Out any, K comparable](ctx context.Context, left <-chan rop.Result[L], right <-chan rop.Result[R],
+	keyL func(l L) K, keyR func(r R) K,
+	combine func(ctx context.Context, l L, r R) rop.Result[Out],
+	window time.Duration) <-chan rop.Result[Out] {
+
+	out := make(chan rop.Result[Out])
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		lefts := map[K][]joinEntry[L]{}
+		rights := map[K][]joinEntry[R]{}
+
+		var prune <-chan time.Time
+		if window > 0 {
+			ticker := time.NewTicker(window)
+			defer ticker.Stop()
+			prune = ticker.C
+		}
+
+		send := func(r rop.Result[Out]) bool {
+			select {
+			case out <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for left != nil || right != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-prune:
+				expire(lefts, now.Add(-window))
+				expire(rights, now.Add(-window))
+			case l, ok := <-left:
+				if !ok {
+					left = nil
+					continue
+				}
+				if !l.IsSuccess() {
+					if !send(passOn[L, Out](l)) {
+						return
+					}
+					continue
+				}
+
+				now := time.Now()
+				key := keyL(l.Result())
+				for _, r := range live(rights, key, now, window) {
+					if !send(combine(ctx, l.Result(), r.value)) {
+						return
+					}
+				}
+				lefts[key] = append(lefts[key], joinEntry[L]{value: l.Result(), at: now})
+			case r, ok := <-right:
+				if !ok {
+					right = nil
+					continue
+				}
+				if !r.IsSuccess() {
+					if !send(passOn[R, Out](r)) {
+						return
+					}
+					continue
+				}
+
+				now := time.Now()
+				key := keyR(r.Result())
+				for _, l := range live(lefts, key, now, window) {
+					if !send(combine(ctx, l.value, r.Result())) {
+						return
+					}
+				}
+				rights[key] = append(rights[key], joinEntry[R]{value: r.Result(), at: now})
+			}
+		}
+	})
+
+	return out
+}
+
+// live returns the entries of key still inside the window, dropping the rest.
+func live[K comparable, T any](entries map[K][]joinEntry[T], key K, now time.Time,
+	window time.Duration) []joinEntry[T] {
+
+	if window > 0 {
+		expireKey(entries, key, now.Add(-window))
+	}
+	return entries[key]
+}
+
+func expire[K comparable, T any](entries map[K][]joinEntry[T], before time.Time) {
+	for key := range entries {
+		expireKey(entries, key, before)
+	}
+}
+
+func expireKey[K comparable, T any](entries map[K][]joinEntry[T], key K, before time.Time) {
+	kept := entries[key][:0]
+	for _, e := range entries[key] {
+		if !e.at.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == 0 {
+		delete(entries, key)
+		return
+	}
+	entries[key] = kept
+}
+
+func passOn[In, Out any](r rop.Result[In]) rop.Result[Out] {
+	if r.IsCancel() {
+		return rop.Cancel[Out](r.Err())
+	}
+	return rop.Fail[Out](r.Err())
+}
@@ -0,0 +1,47 @@
+package mass
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// Limiter hands out execution tokens; *rate.Limiter from golang.org/x/time/rate satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Limited makes stage wait for a token from limiter before running an item.
+// A wait ended by ctx yields a cancel result; any other wait error a failure.
+// Failures and cancels are passed to stage without waiting.
+func Limited[In, Out any](stage func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out],
+	limiter Limiter) func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+
+	return func(ctx context.Context, input rop.Result[In]) <-chan rop.Result[Out] {
+		if !input.IsSuccess() {
+			return stage(ctx, input)
+		}
+
+		out := make(chan rop.Result[Out], 1)
+
+		core.Go(ctx, func() {
+			defer close(out)
+
+			if err := limiter.Wait(ctx); err != nil {
+				if ctx.Err() != nil {
+					out <- rop.Cancel[Out](context.Cause(ctx))
+				} else {
+					out <- rop.Fail[Out](err)
+				}
+				return
+			}
+
+			if pr, ok := <-stage(ctx, input); ok {
+				out <- pr
+			}
+		})
+
+		return out
+	}
+}
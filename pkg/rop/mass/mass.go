@@ -3,6 +3,7 @@ package mass
 import (
 	"context"
 	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
 	"github.com/ib-77/rop3/pkg/rop/solo"
 )
 
@@ -10,118 +11,27 @@ func Validating[T any](ctx context.Context, input rop.Result[T],
 	validate func(ctx context.Context, in T) (valid bool, errMsg string),
 	onCancel func(ctx context.Context, in rop.Result[T])) <-chan rop.Result[T] {
 
-	ch := make(chan rop.Result[T])
-	out := make(chan rop.Result[T])
-
-	go func() {
-		defer close(ch)
-
-		if ctx.Err() == nil {
-
-			if !input.HasResult() {
-				panic("no results!")
-			}
-			ch <- solo.Validate[T](ctx, input.Result(), validate)
-		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
-		}
-	}()
-
-	return out
+	return run(ctx, input, func(ctx context.Context) rop.Result[T] {
+		return solo.AndValidate[T](ctx, input, validate)
+	}, onCancel)
 }
 
 func Switching[In, Out any](ctx context.Context, input rop.Result[In],
 	switchOnSuccess func(ctx context.Context, r In) rop.Result[Out],
 	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
 
-	ch := make(chan rop.Result[Out])
-	out := make(chan rop.Result[Out])
-
-	go func() {
-		defer close(ch)
-
-		if ctx.Err() == nil {
-			ch <- solo.Switch[In, Out](ctx, input, switchOnSuccess)
-		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
-		}
-	}()
-
-	return out
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		return solo.Switch[In, Out](ctx, input, switchOnSuccess)
+	}, onCancel)
 }
 
 func Mapping[In, Out any](ctx context.Context, input rop.Result[In],
 	mapOnSuccess func(ctx context.Context, r In) Out,
 	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
 
-	ch := make(chan rop.Result[Out])
-	out := make(chan rop.Result[Out])
-
-	go func() {
-		defer close(ch)
-
-		if ctx.Err() == nil {
-			ch <- solo.Map[In, Out](ctx, input, mapOnSuccess)
-		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
-		}
-	}()
-
-	return out
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		return solo.Map[In, Out](ctx, input, mapOnSuccess)
+	}, onCancel)
 }
 
 func DoubleMapping[In, Out any](ctx context.Context, input rop.Result[In],
@@ -130,76 +40,18 @@ func DoubleMapping[In, Out any](ctx context.Context, input rop.Result[In],
 	mapOnCancel func(ctx context.Context, err error) Out,
 	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
 
-	ch := make(chan rop.Result[Out])
-	out := make(chan rop.Result[Out])
-
-	go func() {
-		defer close(ch)
-
-		if ctx.Err() == nil {
-			ch <- solo.DoubleMap[In, Out](ctx, input, mapOnSuccess, mapOnError, mapOnCancel)
-		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
-		}
-	}()
-
-	return out
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		return solo.DoubleMap[In, Out](ctx, input, mapOnSuccess, mapOnError, mapOnCancel)
+	}, onCancel)
 }
 
 func Teeing[T any](ctx context.Context, input rop.Result[T],
 	sideEffect func(ctx context.Context, r rop.Result[T]),
 	onCancel func(ctx context.Context, in rop.Result[T])) <-chan rop.Result[T] {
 
-	ch := make(chan rop.Result[T])
-	out := make(chan rop.Result[T])
-
-	go func() {
-		defer close(ch)
-
-		if ctx.Err() == nil {
-			ch <- solo.Tee[T](ctx, input, sideEffect)
-		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
-		}
-	}()
-
-	return out
+	return run(ctx, input, func(ctx context.Context) rop.Result[T] {
+		return solo.Tee[T](ctx, input, sideEffect)
+	}, onCancel)
 }
 
 func DoubleTeeing[T any](ctx context.Context, input rop.Result[T],
@@ -208,76 +60,80 @@ func DoubleTeeing[T any](ctx context.Context, input rop.Result[T],
 	sideEffectOnCancel func(ctx context.Context, err error),
 	onCancel func(ctx context.Context, in rop.Result[T])) <-chan rop.Result[T] {
 
-	ch := make(chan rop.Result[T])
-	out := make(chan rop.Result[T])
-
-	go func() {
-		defer close(ch)
+	return run(ctx, input, func(ctx context.Context) rop.Result[T] {
+		return solo.DoubleTee[T](ctx, input, sideEffect, sideEffectOnError, sideEffectOnCancel)
+	}, onCancel)
+}
 
-		if ctx.Err() == nil {
-			ch <- solo.DoubleTee[T](ctx, input, sideEffect, sideEffectOnError, sideEffectOnCancel)
-		}
+func Trying[In, Out any](ctx context.Context, input rop.Result[In],
+	onTryExecute func(ctx context.Context, r In) (Out, error),
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
 
-	}()
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		return solo.Try[In, Out](ctx, input, onTryExecute)
+	}, onCancel)
+}
 
-	go func() {
-		defer close(out)
+// TryingWithRetries is Trying that re-runs onTryExecute, up to attempts times
+// in total, while it fails with an error accepted by retryIf (nil retries every
+// failure), sleeping backoff(attempt) in between. A failure that was retried is
+// returned as a *core.RetryError. Failed and canceled inputs are passed on
+// without a try; cancellation errors are not retried and a sleep interrupted
+// by ctx yields a cancel result.
+func TryingWithRetries[In, Out any](ctx context.Context, input rop.Result[In],
+	onTryExecute func(ctx context.Context, r In) (Out, error),
+	attempts int, backoff core.Backoff, retryIf func(err error) bool,
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
 
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
+	if !input.IsSuccess() {
+		return Trying(ctx, input, onTryExecute, onCancel)
+	}
+
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		for attempt := 1; ; attempt++ {
+			pr := solo.Try[In, Out](ctx, input, onTryExecute)
+			if pr.IsSuccess() || pr.IsCancel() || attempt >= attempts ||
+				(retryIf != nil && !retryIf(pr.Err())) {
+				if attempt > 1 && !pr.IsSuccess() && !pr.IsCancel() {
+					pr = rop.Fail[Out](&core.RetryError{Attempts: attempt, Err: pr.Err()})
 				}
+				return pr
 			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
+
+			if backoff != nil {
+				if err := core.Sleep(ctx, backoff(attempt)); err != nil {
+					return rop.Cancel[Out](err)
+				}
 			}
 		}
-	}()
-
-	return out
+	}, onCancel)
 }
 
-func Trying[In, Out any](ctx context.Context, input rop.Result[In],
-	onTryExecute func(ctx context.Context, r In) (Out, error),
-	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
-
-	ch := make(chan rop.Result[Out])
-	out := make(chan rop.Result[Out])
-
-	go func() {
-		defer close(ch)
+// Filtering passes on successful items matching pred and emits nothing for the
+// others, handing them to onDrop. Failures and cancels are passed on as they are.
+func Filtering[T any](ctx context.Context, input rop.Result[T],
+	pred func(ctx context.Context, in T) bool,
+	onDrop func(ctx context.Context, in rop.Result[T]),
+	onCancel func(ctx context.Context, in rop.Result[T])) <-chan rop.Result[T] {
 
-		if ctx.Err() == nil {
-			ch <- solo.Try[In, Out](ctx, input, onTryExecute)
+	return runMaybe(ctx, input, func(ctx context.Context) (rop.Result[T], bool) {
+		if !input.IsSuccess() || pred(ctx, input.Result()) {
+			return input, true
 		}
-
-	}()
-
-	go func() {
-		defer close(out)
-
-		select {
-		case pr, ok := <-ch:
-			if ok {
-				out <- pr
-			} else {
-				if onCancel != nil {
-					onCancel(ctx, input)
-				}
-			}
-		case <-ctx.Done():
-			if onCancel != nil {
-				onCancel(ctx, input)
-			}
+		if onDrop != nil {
+			onDrop(ctx, input)
 		}
-	}()
+		return input, false
+	}, onCancel)
+}
 
-	return out
+func Stepping[In, Out any](ctx context.Context, input rop.Result[In],
+	step func(ctx context.Context, input rop.Result[In]) rop.Result[Out],
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
+
+	return run(ctx, input, func(ctx context.Context) rop.Result[Out] {
+		return step(ctx, input)
+	}, onCancel)
 }
 
 type FinallyHandlers[In, Out any] struct {
@@ -301,10 +157,24 @@ func Finalizing[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 	cancelHandlers FinallyCancelHandlers[In, Out],
 	onSuccessResult func(ctx context.Context, out Out)) <-chan Out {
 
+	out, _ := FinalizingTracked(ctx, inputCh, handlers, cancelHandlers, onSuccessResult)
+	return out
+}
+
+// FinalizingTracked is Finalizing that also returns a channel closed once the
+// input side has stopped, after any cancel handler for the input ran. On cancel
+// the output may be closed before that.
+func FinalizingTracked[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	handlers FinallyHandlers[In, Out],
+	cancelHandlers FinallyCancelHandlers[In, Out],
+	onSuccessResult func(ctx context.Context, out Out)) (<-chan Out, <-chan struct{}) {
+
 	ch := make(chan Out)
 	out := make(chan Out)
+	finished := make(chan struct{})
 
-	go func() {
+	core.Go(ctx, func() {
+		defer close(finished)
 		defer close(ch)
 
 		if ctx.Err() != nil {
@@ -350,9 +220,9 @@ func Finalizing[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 				}
 			}
 		}
-	}()
+	})
 
-	go func() {
+	core.Go(ctx, func() {
 		defer close(out)
 
 		for {
@@ -380,7 +250,7 @@ func Finalizing[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
 				}
 			}
 		}
-	}()
+	})
 
-	return out
+	return out, finished
 }
@@ -0,0 +1,63 @@
+package mass
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestBatching_FlushRemainingBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		processRemaining bool
+		expected         [][]int
+	}{
+		{true, [][]int{{1, 2}}},
+		{false, nil},
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(core.WithProcessOptions(context.Background(), tt.processRemaining))
+
+		in := make(chan rop.Result[int])
+		out := Batching(ctx, in, 10, time.Minute, BatchingCancelHandlers[int]{
+			OnCancelBatch: FlushRemainingBatch[int],
+		})
+
+		in <- rop.Success(1)
+		in <- rop.Success(2)
+		cancel()
+
+		var got [][]int
+		for r := range out {
+			if !r.IsSuccess() {
+				t.Fatalf("unexpected result: %v", r.Err())
+			}
+			got = append(got, r.Result())
+		}
+		if !slices.EqualFunc(got, tt.expected, slices.Equal) {
+			t.Fatalf("process remaining %v: expected %v, got %v", tt.processRemaining, tt.expected, got)
+		}
+	}
+}
+
+func TestBatching_DropsPartialBatchWithoutHandlers(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int])
+	out := Batching(ctx, in, 10, time.Minute, BatchingCancelHandlers[int]{})
+
+	in <- rop.Success(1)
+	cancel()
+
+	for r := range out {
+		t.Fatalf("expected the partial batch dropped, got %v", r.Result())
+	}
+}
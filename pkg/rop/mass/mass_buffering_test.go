@@ -0,0 +1,143 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestBuffering_Policies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy   core.OverflowPolicy
+		expected []string
+	}{
+		{core.OverflowDropOldest, []string{"4", "5"}},
+		{core.OverflowDropNewest, []string{"1", "2"}},
+		{core.OverflowFail, []string{"1", "2", "full", "full", "full"}},
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		in := make(chan rop.Result[int], 5)
+		for v := 1; v <= 5; v++ {
+			in <- rop.Success(v)
+		}
+		close(in)
+
+		out := Buffering(ctx, in, 2, tt.policy)
+
+		// nobody reads out yet: the buffer keeps draining the input anyway
+		waitDrained(t, in)
+
+		var got []string
+		for r := range out {
+			switch {
+			case r.IsSuccess():
+				got = append(got, strconv.Itoa(r.Result()))
+			case errors.Is(r.Err(), ErrBufferFull):
+				got = append(got, "full")
+			}
+		}
+		if !slices.Equal(got, tt.expected) {
+			t.Fatalf("policy %d: expected %v, got %v", tt.policy, tt.expected, got)
+		}
+	}
+}
+
+func TestBuffering_BlockHoldsProducer(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 5)
+	for v := 1; v <= 5; v++ {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	out := Buffering(ctx, in, 2, core.OverflowBlock)
+	time.Sleep(50 * time.Millisecond)
+	if n := len(in); n != 3 {
+		t.Fatalf("expected 3 items held back, got %d", n)
+	}
+	if n := len(core.FromChanMany(ctx, out)); n != 5 {
+		t.Fatalf("expected 5 results, got %d", n)
+	}
+}
+
+func TestBuffering_FailRunsInOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 100)
+	for v := 1; v <= 100; v++ {
+		in <- rop.Success(v)
+	}
+	close(in)
+
+	out := Buffering(ctx, in, 2, core.OverflowFail)
+	waitDrained(t, in)
+
+	got := core.FromChanMany(ctx, out)
+	if len(got) != 100 || got[0].Result() != 1 || got[1].Result() != 2 {
+		t.Fatalf("expected 2 results then the failures, got %d results", len(got))
+	}
+	for _, r := range got[2:] {
+		if !errors.Is(r.Err(), ErrBufferFull) {
+			t.Fatalf("expected ErrBufferFull, got %v", r)
+		}
+	}
+}
+
+func TestBuffering_DrainsAfterCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	in := make(chan rop.Result[int])
+	out := Buffering(ctx, in, 1, core.OverflowDropOldest)
+
+	// an upstream cancel handler still gets its cancels through
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		for range 3 {
+			in <- rop.Cancel[int](context.Canceled)
+		}
+		close(in)
+	}()
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the input drained after cancel")
+	}
+	if got := core.FromChanMany(context.Background(), out); len(got) != 1 || !got[0].IsCancel() {
+		t.Fatalf("expected the last cancel kept, got %v", got)
+	}
+}
+
+func waitDrained(t *testing.T, in chan rop.Result[int]) {
+	t.Helper()
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for len(in) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(in) > 0 {
+		t.Fatalf("expected the input drained, %d left", len(in))
+	}
+}
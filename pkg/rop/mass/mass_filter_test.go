@@ -0,0 +1,60 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func even(ctx context.Context, v int) bool { return v%2 == 0 }
+
+func TestFiltering(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	inputs := []rop.Result[int]{rop.Success(1), rop.Success(2), rop.Success(3), rop.Success(4),
+		rop.Success(5), rop.Fail[int](errors.New("bad"))}
+
+	var successes, failures, dropped int
+	for _, in := range inputs {
+		for r := range Filtering(ctx, in, even, func(ctx context.Context, in rop.Result[int]) { dropped++ }, nil) {
+			if r.IsSuccess() {
+				successes++
+			} else {
+				failures++
+			}
+		}
+	}
+
+	if successes != 2 || failures != 1 || dropped != 3 {
+		t.Fatalf("expected 2 even values, the failure and 3 dropped, got %d, %d and %d",
+			successes, failures, dropped)
+	}
+	if ctx.Err() != nil {
+		t.Fatalf("filtering stalled until the deadline")
+	}
+}
+
+func TestFiltering_Fused(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(core.WithExecutionOptions(context.Background(), core.ExecuteFused), time.Second)
+	defer cancel()
+
+	var successes int
+	for v := 1; v <= 4; v++ {
+		for range Filtering(ctx, rop.Success(v), even, nil, nil) {
+			successes++
+		}
+	}
+
+	if successes != 2 {
+		t.Fatalf("expected 2 even values, got %d", successes)
+	}
+}
@@ -0,0 +1,94 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type order struct {
+	ID       int
+	Customer string
+}
+
+type customer struct {
+	ID   string
+	Name string
+}
+
+// collect drains ch into its successful values and errors.
+func collect[T any](ch <-chan rop.Result[T]) (successes []T, errs []error) {
+	for r := range ch {
+		if r.IsSuccess() {
+			successes = append(successes, r.Result())
+		} else {
+			errs = append(errs, r.Err())
+		}
+	}
+	return successes, errs
+}
+
+func TestJoining_ByKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	orders := make(chan rop.Result[order], 4)
+	orders <- rop.Success(order{ID: 1, Customer: "c1"})
+	orders <- rop.Success(order{ID: 2, Customer: "c2"})
+	orders <- rop.Success(order{ID: 3, Customer: "c1"})
+	orders <- rop.Fail[order](errors.New("bad order"))
+	close(orders)
+
+	customers := make(chan rop.Result[customer], 2)
+	customers <- rop.Success(customer{ID: "c1", Name: "Ann"})
+	customers <- rop.Success(customer{ID: "c9", Name: "Nobody"})
+	close(customers)
+
+	joined := Joining(ctx, orders, customers,
+		func(o order) string { return o.Customer },
+		func(c customer) string { return c.ID },
+		func(ctx context.Context, o order, c customer) rop.Result[string] {
+			return rop.Success(c.Name + "#" + strconv.Itoa(o.ID))
+		}, 0)
+
+	successes, failures := collect(joined)
+	sort.Strings(successes)
+
+	if len(successes) != 2 || successes[0] != "Ann#1" || successes[1] != "Ann#3" || len(failures) != 1 {
+		t.Fatalf("expected [Ann#1 Ann#3] and 1 failure, got %v and %v", successes, failures)
+	}
+}
+
+func TestJoining_Window(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	left := make(chan rop.Result[int])
+	right := make(chan rop.Result[int])
+	go func() {
+		defer close(left)
+		defer close(right)
+		left <- rop.Success(1)
+		time.Sleep(60 * time.Millisecond)
+		right <- rop.Success(1) // too late for left 1
+		right <- rop.Success(2)
+		left <- rop.Success(2)
+	}()
+
+	id := func(v int) int { return v }
+	successes, _ := collect(Joining(ctx, left, right, id, id,
+		func(ctx context.Context, l, r int) rop.Result[int] { return rop.Success(l + r) }, 20*time.Millisecond))
+
+	if len(successes) != 1 || successes[0] != 4 {
+		t.Fatalf("expected only the pair within the window, got %v", successes)
+	}
+}
@@ -0,0 +1,71 @@
+package mass
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// tickLimiter hands out one token per tick.
+type tickLimiter struct {
+	ticks <-chan time.Time
+}
+
+func (l tickLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.ticks:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func identity(ctx context.Context, input rop.Result[int]) <-chan rop.Result[int] {
+	return Mapping(ctx, input, func(ctx context.Context, v int) int { return v }, nil)
+}
+
+func TestLimited_WaitsForTokens(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	engine := Limited(identity, tickLimiter{ticks: ticker.C})
+
+	start := time.Now()
+	var pending []<-chan rop.Result[int]
+	for v := range 4 {
+		pending = append(pending, engine(ctx, rop.Success(v)))
+	}
+	var results []rop.Result[int]
+	for _, ch := range pending {
+		results = append(results, core.FromChanMany(ctx, ch)...)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Fatalf("expected items to be paced by the limiter, took only %v", elapsed)
+	}
+}
+
+func TestLimited_CanceledWait(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	engine := Limited(identity, tickLimiter{})
+
+	r := <-engine(ctx, rop.Success(1))
+	if !r.IsCancel() {
+		t.Fatalf("expected a cancel result when the wait outlives ctx, got err=%v", r.Err())
+	}
+}
@@ -0,0 +1,85 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestFinalizingOrdered_KeepsInputOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 8)
+	for v := range 7 {
+		in <- rop.Success(v)
+	}
+	in <- rop.Fail[int](errors.New("bad"))
+	close(in)
+
+	handlers := FinallyHandlers[int, string]{
+		OnSuccess: func(ctx context.Context, v int) string {
+			// earlier items finish last
+			time.Sleep(time.Duration(8-v) * time.Millisecond)
+			return strconv.Itoa(v)
+		},
+		OnError:  func(ctx context.Context, err error) string { return "error" },
+		OnCancel: func(ctx context.Context, err error) string { return "cancel" },
+	}
+
+	var emitted []string
+	var got []string
+	for v := range FinalizingOrdered(ctx, in, handlers, FinallyCancelHandlers[int, string]{},
+		func(ctx context.Context, out string) { emitted = append(emitted, out) }, 4) {
+		got = append(got, v)
+	}
+
+	expected := []string{"0", "1", "2", "3", "4", "5", "6", "error"}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	if !slices.Equal(emitted, expected) {
+		t.Fatalf("expected onSuccessResult for every value in order, got %v", emitted)
+	}
+}
+
+func TestFinalizingOrdered_Cancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan rop.Result[int])
+	release := make(chan struct{})
+	handlers := DefaultFinallyHandlers(func(ctx context.Context, v int) int {
+		if v == 0 {
+			<-release
+		}
+		return v
+	})
+
+	var held []int
+	out := FinalizingOrdered(ctx, in, handlers, FinallyCancelHandlers[int, int]{
+		OnCancelResult: func(ctx context.Context, v int, outCh chan<- int) { held = append(held, v) },
+	}, nil, 2)
+
+	in <- rop.Success(0)
+	in <- rop.Success(1)
+	in <- rop.Success(2)
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	close(release)
+
+	for v := range out {
+		t.Fatalf("expected nothing emitted while 0 was held back, got %d", v)
+	}
+	if !slices.Equal(held, []int{1, 2}) {
+		t.Fatalf("expected the held back values in order, got %v", held)
+	}
+}
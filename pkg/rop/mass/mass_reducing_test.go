@@ -0,0 +1,66 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func countOutcomes(ctx context.Context, acc [2]int, in rop.Result[int]) [2]int {
+	if in.IsSuccess() {
+		acc[0] += in.Result()
+	} else {
+		acc[1]++
+	}
+	return acc
+}
+
+func TestReducing(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in := make(chan rop.Result[int], 4)
+	in <- rop.Success(1)
+	in <- rop.Success(2)
+	in <- rop.Fail[int](errors.New("bad"))
+	in <- rop.Success(3)
+	close(in)
+
+	r := <-Reducing(ctx, in, [2]int{}, countOutcomes, ReducingCancelHandlers[[2]int]{})
+	if !r.IsSuccess() || r.Result() != [2]int{6, 1} {
+		t.Fatalf("expected sum 6 with 1 failure, got: success=%v, val=%v", r.IsSuccess(), r.Result())
+	}
+}
+
+func TestReducing_Canceled(t *testing.T) {
+	t.Parallel()
+
+	in := make(chan rop.Result[int], 1)
+	in <- rop.Success(5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defaultOut := Reducing(ctx, core.LiftChan(ctx, make(chan int)), [2]int{}, countOutcomes,
+		ReducingCancelHandlers[[2]int]{})
+
+	partialOut := Reducing(ctx, in, [2]int{}, countOutcomes, ReducingCancelHandlers[[2]int]{
+		OnCancelPartial: func(ctx context.Context, partial [2]int) rop.Result[[2]int] {
+			return rop.Success(partial)
+		},
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if r := <-defaultOut; !r.IsCancel() || !errors.Is(r.Err(), context.Canceled) {
+		t.Fatalf("expected a cancel result by default, got err=%v", r.Err())
+	}
+	if r := <-partialOut; !r.IsSuccess() || r.Result() != [2]int{5, 0} {
+		t.Fatalf("expected the partial aggregate, got: success=%v, val=%v", r.IsSuccess(), r.Result())
+	}
+}
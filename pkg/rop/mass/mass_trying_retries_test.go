@@ -0,0 +1,94 @@
+package mass
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+var errTransient = errors.New("transient")
+
+func TestTryingWithRetries(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	calls := 0
+	flaky := func(ctx context.Context, v int) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errTransient
+		}
+		return v * 2, nil
+	}
+
+	r := <-TryingWithRetries(ctx, rop.Success(21), flaky, 5, core.ConstantBackoff(time.Millisecond),
+		func(err error) bool { return errors.Is(err, errTransient) }, nil)
+	if !r.IsSuccess() || r.Result() != 42 || calls != 3 {
+		t.Fatalf("expected success 42 on the 3rd call, got: success=%v, val=%v, calls=%d", r.IsSuccess(), r.Result(), calls)
+	}
+}
+
+func TestTryingWithRetries_GivesUp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	calls := 0
+	r := <-TryingWithRetries(ctx, rop.Success(1), func(ctx context.Context, v int) (int, error) {
+		calls++
+		return 0, errTransient
+	}, 3, nil, nil, nil)
+
+	var retryErr *core.RetryError
+	if !errors.As(r.Err(), &retryErr) || retryErr.Attempts != 3 || !errors.Is(r.Err(), errTransient) || calls != 3 {
+		t.Fatalf("expected the transient error after 3 calls, got %v after %d", r.Err(), calls)
+	}
+}
+
+func TestTryingWithRetries_BackoffCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	out := TryingWithRetries(ctx, rop.Success(1), func(ctx context.Context, v int) (int, error) {
+		return 0, errTransient
+	}, 3, core.ConstantBackoff(time.Minute), nil, nil)
+
+	if r, ok := <-out; ok && !r.IsCancel() {
+		t.Fatalf("expected no result or a cancel, got err=%v", r.Err())
+	}
+}
+
+func TestTryingWithRetries_FailedInputPassedOn(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	errUpstream := errors.New("upstream")
+	calls := 0
+	start := time.Now()
+	r := <-TryingWithRetries(ctx, rop.Fail[int](errUpstream), func(ctx context.Context, v int) (int, error) {
+		calls++
+		return v, nil
+	}, 3, core.ConstantBackoff(time.Minute), nil, nil)
+
+	if r.IsCancel() || !errors.Is(r.Err(), errUpstream) || calls != 0 {
+		t.Fatalf("expected the upstream failure passed on untried, got %v after %d calls", r.Err(), calls)
+	}
+	var retryErr *core.RetryError
+	if errors.As(r.Err(), &retryErr) {
+		t.Fatalf("expected no retry error for an untried input")
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected no backoff for a failed input, took %v", elapsed)
+	}
+}
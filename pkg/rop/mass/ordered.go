@@ -0,0 +1,156 @@
+package mass
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/solo"
+)
+
+type numbered[T any] struct {
+	seq int64
+	v   T
+}
+
+// FinalizingOrdered is Finalizing with the values finalized by workers
+// goroutines and still emitted in input order: every input is numbered as it is
+// admitted and its value held back until those of all earlier inputs are out.
+// A slow item holds back every value behind it.
+// On cancel, as with Finalizing, the items being finalized go to OnCancelValue
+// and the remaining input to OnCancelValues; the values held back go to
+// OnCancelResult in input order.
+func FinalizingOrdered[In, Out any](ctx context.Context, inputCh <-chan rop.Result[In],
+	handlers FinallyHandlers[In, Out],
+	cancelHandlers FinallyCancelHandlers[In, Out],
+	onSuccessResult func(ctx context.Context, out Out), workers int) <-chan Out {
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	out := make(chan Out)
+	work := make(chan numbered[rop.Result[In]])
+	results := make(chan numbered[Out])
+	// writers are the goroutines that may send on out
+	writers := &sync.WaitGroup{}
+
+	onCancelValue := func(in rop.Result[In]) {
+		if cancelHandlers.OnCancelValue != nil {
+			cancelHandlers.OnCancelValue(ctx, in, cancelHandlers.OnBreak, out)
+		}
+	}
+	onCancelValues := func() {
+		if cancelHandlers.OnCancelValues != nil {
+			cancelHandlers.OnCancelValues(ctx, inputCh, cancelHandlers.OnBreak, out)
+		}
+	}
+
+	writers.Add(1)
+	core.Go(ctx, func() {
+		defer writers.Done()
+		defer close(work)
+
+		for seq := int64(0); ; seq++ {
+			select {
+			case <-ctx.Done():
+				onCancelValues()
+				return
+			case in, ok := <-inputCh:
+				if !ok {
+					return
+				}
+				select {
+				case work <- numbered[rop.Result[In]]{seq: seq, v: in}:
+				case <-ctx.Done():
+					onCancelValue(in)
+					onCancelValues()
+					return
+				}
+			}
+		}
+	})
+
+	lines := &sync.WaitGroup{}
+	for range workers {
+		writers.Add(1)
+		lines.Add(1)
+		core.Go(ctx, func() {
+			defer writers.Done()
+			defer lines.Done()
+
+			for item := range work {
+				res := solo.Finally[In, Out](ctx, item.v, handlers.OnSuccess, handlers.OnError, handlers.OnCancel)
+				if ctx.Err() != nil {
+					onCancelValue(item.v)
+					continue
+				}
+
+				select {
+				case results <- numbered[Out]{seq: item.seq, v: res}:
+				case <-ctx.Done():
+					onCancelValue(item.v)
+				}
+			}
+		})
+	}
+
+	core.Go(ctx, func() {
+		lines.Wait()
+		close(results)
+	})
+
+	writers.Add(1)
+	core.Go(ctx, func() {
+		defer writers.Done()
+
+		ready := map[int64]Out{}
+		onCancelResults := func() {
+			if cancelHandlers.OnCancelResult == nil {
+				return
+			}
+			for _, seq := range slices.Sorted(maps.Keys(ready)) {
+				cancelHandlers.OnCancelResult(ctx, ready[seq], out)
+			}
+		}
+
+		var next int64
+		for {
+			select {
+			case <-ctx.Done():
+				onCancelResults()
+				return
+			case r, ok := <-results:
+				if !ok {
+					return
+				}
+				ready[r.seq] = r.v
+
+				for finalized, found := ready[next]; found; finalized, found = ready[next] {
+					select {
+					case <-ctx.Done():
+						// finalized is still ready at next, so it goes first
+						onCancelResults()
+						return
+					case out <- finalized:
+						if onSuccessResult != nil {
+							onSuccessResult(ctx, finalized)
+						}
+					}
+					delete(ready, next)
+					next++
+				}
+			}
+		}
+	})
+
+	core.Go(ctx, func() {
+		writers.Wait()
+		close(out)
+	})
+
+	return out
+}
@@ -0,0 +1,50 @@
+package mass
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+type ReducingCancelHandlers[Acc any] struct {
+	// OnCancelPartial decides what is emitted for the partial aggregate when ctx
+	// is done before inputCh closes. By default a cancel result carrying the
+	// context cause is emitted and the partial aggregate is dropped.
+	OnCancelPartial func(ctx context.Context, partial Acc) rop.Result[Acc]
+}
+
+// Reducing folds every result of inputCh into seed with step and emits a single
+// success with the aggregate once inputCh closes. step sees failures and cancels
+// too, so it decides how they count.
+func Reducing[In, Acc any](ctx context.Context, inputCh <-chan rop.Result[In], seed Acc,
+	step func(ctx context.Context, acc Acc, in rop.Result[In]) Acc,
+	cancelHandlers ReducingCancelHandlers[Acc]) <-chan rop.Result[Acc] {
+
+	out := make(chan rop.Result[Acc], 1)
+
+	core.Go(ctx, func() {
+		defer close(out)
+
+		acc := seed
+		for {
+			select {
+			case <-ctx.Done():
+				if cancelHandlers.OnCancelPartial != nil {
+					out <- cancelHandlers.OnCancelPartial(ctx, acc)
+				} else {
+					out <- rop.Cancel[Acc](context.Cause(ctx))
+				}
+				return
+			case in, ok := <-inputCh:
+				if !ok {
+					out <- rop.Success(acc)
+					return
+				}
+				acc = step(ctx, acc, in)
+			}
+		}
+	})
+
+	return out
+}
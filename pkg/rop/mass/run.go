@@ -0,0 +1,142 @@
+package mass
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// run executes a per-item stage. By default exec runs in its own goroutine
+// raced against ctx by a second one, so a canceled item is let go at once.
+// With core.ExecuteFused exec runs inline and the result is handed over in a
+// buffered channel, with no goroutines or extra channel hops per item.
+// Failures are attributed to the stage named in ctx (core.WithStageName).
+//
+// With core.WithStageTimeout exec gets a child context with that deadline and
+// an expired item is handed to onCancel and yields a cancel result instead of
+// holding up the line (in fused mode the expiry is only observed once exec returns).
+//
+// With core.WithRecoverOptions a panic in exec is emitted as a failure
+// carrying a *rop.PanicError (value and stack).
+func run[In, Out any](ctx context.Context, input rop.Result[In], exec func(ctx context.Context) rop.Result[Out],
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
+	return runMaybe(ctx, input, func(ctx context.Context) (rop.Result[Out], bool) {
+		return exec(ctx), true
+	}, onCancel)
+}
+
+// runMaybe is run for stages that may emit nothing for an item (exec returns false).
+func runMaybe[In, Out any](ctx context.Context, input rop.Result[In],
+	exec func(ctx context.Context) (rop.Result[Out], bool),
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
+
+	if core.IsRecoverEnabled(ctx, false) {
+		exec = recovering(exec)
+	}
+
+	if core.GetExecutionMode(ctx, core.ExecuteAsync) == core.ExecuteFused {
+		return runFused(ctx, input, exec, onCancel)
+	}
+
+	itemCtx, cancelItem := stageContext(ctx)
+
+	ch := make(chan rop.Result[Out], 1)
+	out := make(chan rop.Result[Out])
+	// closed when exec kept nothing, so an empty ch is not taken for a cancel
+	skipped := make(chan struct{})
+
+	core.Go(ctx, func() {
+		defer close(ch)
+
+		if ctx.Err() == nil {
+			if pr, keep := exec(itemCtx); keep {
+				ch <- core.AttributeStage(ctx, input, pr)
+			} else {
+				close(skipped)
+			}
+		}
+	})
+
+	core.Go(ctx, func() {
+		defer close(out)
+		defer cancelItem()
+
+		select {
+		case pr, ok := <-ch:
+			if ok {
+				out <- pr
+			} else {
+				select {
+				case <-skipped:
+				default:
+					if onCancel != nil {
+						onCancel(ctx, input)
+					}
+				}
+			}
+		case <-itemCtx.Done():
+			if onCancel != nil {
+				onCancel(ctx, input)
+			}
+			if ctx.Err() == nil {
+				out <- core.AttributeStage(ctx, input, rop.Cancel[Out](context.Cause(itemCtx)))
+			}
+		}
+	})
+
+	return out
+}
+
+func runFused[In, Out any](ctx context.Context, input rop.Result[In],
+	exec func(ctx context.Context) (rop.Result[Out], bool),
+	onCancel func(ctx context.Context, in rop.Result[In])) <-chan rop.Result[Out] {
+
+	out := make(chan rop.Result[Out], 1)
+	defer close(out)
+
+	if ctx.Err() == nil {
+		itemCtx, cancelItem := stageContext(ctx)
+		pr, keep := exec(itemCtx)
+		expired := itemCtx.Err() != nil
+		cancelItem()
+
+		if ctx.Err() == nil {
+			if expired {
+				if onCancel != nil {
+					onCancel(ctx, input)
+				}
+				pr, keep = rop.Cancel[Out](context.Cause(itemCtx)), true
+			}
+			if keep {
+				out <- core.AttributeStage(ctx, input, pr)
+			}
+			return out
+		}
+	}
+
+	if onCancel != nil {
+		onCancel(ctx, input)
+	}
+	return out
+}
+
+func stageContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d := core.GetStageTimeout(ctx); d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return ctx, func() {}
+}
+
+func recovering[Out any](exec func(ctx context.Context) (rop.Result[Out], bool)) func(
+	ctx context.Context) (rop.Result[Out], bool) {
+
+	return func(ctx context.Context) (pr rop.Result[Out], keep bool) {
+		defer func() {
+			if v := recover(); v != nil {
+				pr, keep = rop.Fail[Out](rop.NewPanicError(v)), true
+			}
+		}()
+		return exec(ctx)
+	}
+}
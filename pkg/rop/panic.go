@@ -0,0 +1,30 @@
+package rop
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic converted into an error.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// NewPanicError wraps a recovered value, capturing the current goroutine stack.
+// It is meant to be called from the deferred function that recovered.
+func NewPanicError(value any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack()}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap exposes the panic value when it is an error itself
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package solo
+
+import (
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+var ErrNoResults = errors.New("no results")
+
+// All succeeds with every value when all inputs succeed. Otherwise a cancel
+// wins over failures and the returned error joins the errors of all
+// non-successful inputs in order.
+func All[T any](rs ...rop.Result[T]) rop.Result[[]T] {
+	values := make([]T, 0, len(rs))
+	var errs []error
+	canceled := false
+
+	for _, r := range rs {
+		if r.IsSuccess() {
+			values = append(values, r.Result())
+			continue
+		}
+		canceled = canceled || r.IsCancel()
+		errs = append(errs, r.Err())
+	}
+
+	if len(errs) == 0 {
+		return rop.Success(values)
+	}
+	if canceled {
+		return rop.Cancel[[]T](errors.Join(errs...))
+	}
+	return rop.Fail[[]T](errors.Join(errs...))
+}
+
+// Any returns the first successful input. Otherwise the returned error joins
+// all input errors; the result is a cancel only when every input was canceled.
+func Any[T any](rs ...rop.Result[T]) rop.Result[T] {
+	if len(rs) == 0 {
+		return rop.Fail[T](ErrNoResults)
+	}
+
+	errs := make([]error, 0, len(rs))
+	allCanceled := true
+
+	for _, r := range rs {
+		if r.IsSuccess() {
+			return r
+		}
+		allCanceled = allCanceled && r.IsCancel()
+		errs = append(errs, r.Err())
+	}
+
+	if allCanceled {
+		return rop.Cancel[T](errors.Join(errs...))
+	}
+	return rop.Fail[T](errors.Join(errs...))
+}
@@ -0,0 +1,64 @@
+package solo
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func SwitchStep[In, Out any](onSuccess func(ctx context.Context, r In) rop.Result[Out]) func(ctx context.Context,
+	input rop.Result[In]) rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) rop.Result[Out] {
+		return Switch(ctx, input, onSuccess)
+	}
+}
+
+func MapStep[In, Out any](onSuccess func(ctx context.Context, r In) Out) func(ctx context.Context,
+	input rop.Result[In]) rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) rop.Result[Out] {
+		return Map(ctx, input, onSuccess)
+	}
+}
+
+func TryStep[In, Out any](onTryExecute func(ctx context.Context, r In) (Out, error)) func(ctx context.Context,
+	input rop.Result[In]) rop.Result[Out] {
+	return func(ctx context.Context, input rop.Result[In]) rop.Result[Out] {
+		return Try(ctx, input, onTryExecute)
+	}
+}
+
+func Compose2[A, B, C any](
+	first func(ctx context.Context, input rop.Result[A]) rop.Result[B],
+	second func(ctx context.Context, input rop.Result[B]) rop.Result[C]) func(ctx context.Context,
+	input rop.Result[A]) rop.Result[C] {
+	return func(ctx context.Context, input rop.Result[A]) rop.Result[C] {
+		return second(ctx, first(ctx, input))
+	}
+}
+
+func Compose3[A, B, C, D any](
+	first func(ctx context.Context, input rop.Result[A]) rop.Result[B],
+	second func(ctx context.Context, input rop.Result[B]) rop.Result[C],
+	third func(ctx context.Context, input rop.Result[C]) rop.Result[D]) func(ctx context.Context,
+	input rop.Result[A]) rop.Result[D] {
+	return Compose2(Compose2(first, second), third)
+}
+
+func Compose4[A, B, C, D, E any](
+	first func(ctx context.Context, input rop.Result[A]) rop.Result[B],
+	second func(ctx context.Context, input rop.Result[B]) rop.Result[C],
+	third func(ctx context.Context, input rop.Result[C]) rop.Result[D],
+	fourth func(ctx context.Context, input rop.Result[D]) rop.Result[E]) func(ctx context.Context,
+	input rop.Result[A]) rop.Result[E] {
+	return Compose2(Compose3(first, second, third), fourth)
+}
+
+func Compose5[A, B, C, D, E, F any](
+	first func(ctx context.Context, input rop.Result[A]) rop.Result[B],
+	second func(ctx context.Context, input rop.Result[B]) rop.Result[C],
+	third func(ctx context.Context, input rop.Result[C]) rop.Result[D],
+	fourth func(ctx context.Context, input rop.Result[D]) rop.Result[E],
+	fifth func(ctx context.Context, input rop.Result[E]) rop.Result[F]) func(ctx context.Context,
+	input rop.Result[A]) rop.Result[F] {
+	return Compose2(Compose4(first, second, third, fourth), fifth)
+}
@@ -0,0 +1,17 @@
+package solo
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// FromContext converts a done context into a Cancel result carrying
+// context.Cause(ctx). Live contexts and non-success inputs pass through unchanged.
+func FromContext[T any](ctx context.Context, input rop.Result[T]) rop.Result[T] {
+	if !input.IsSuccess() || ctx.Err() == nil {
+		return input
+	}
+
+	return rop.Cancel[T](context.Cause(ctx))
+}
@@ -5,9 +5,17 @@
 // Highlights:
 // - Success/Fail/Cancel: construct Result[T]
 // - Validate/AndValidate: apply validation producing failure on invalid input
+// - ValidateAllNamed: like ValidateAll, attributing failures to named validators
 // - Switch: move from Result[In] to Result[Out]
 // - Map/DoubleMap: transform successful values (with optional error/cancel maps)
 // - Try: call a function (Out, error) and convert error to failure
 // - Tee/TeeIf/DoubleTee: side-effect helpers
+// - TryTee: side effect that may fail, converting its error to failure
 // - Finally: reduce to a concrete value via success/error/cancel handlers
+// - Compose2..Compose5: fuse Switch/Map/Try steps (see SwitchStep, MapStep,
+//   TryStep) into one step that lite.Step lifts as a single engine
+// - All/Any: fan-in of several results (all must succeed / first success wins)
+// - FromContext: surface a done context as a Cancel result carrying its cause
+// - Lazy/OrElse: deferred results so failure fallbacks are only computed when needed
+// - Memoize: cache successful Try results by key with an optional TTL
 package solo
\ No newline at end of file
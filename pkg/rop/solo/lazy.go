@@ -0,0 +1,33 @@
+package solo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Lazy returns a thunk deferring eval until it is first called (forced); eval
+// runs at most once and later calls return its result.
+func Lazy[T any](eval func(ctx context.Context) rop.Result[T]) func(ctx context.Context) rop.Result[T] {
+	var once sync.Once
+	var result rop.Result[T]
+
+	return func(ctx context.Context) rop.Result[T] {
+		once.Do(func() {
+			result = eval(ctx)
+			eval = nil
+		})
+		return result
+	}
+}
+
+// OrElse returns input unless it is a failure, in which case the fallback is forced.
+// Cancels are propagated without evaluating the fallback.
+func OrElse[T any](ctx context.Context, input rop.Result[T],
+	fallback func(ctx context.Context) rop.Result[T]) rop.Result[T] {
+	if input.IsSuccess() || input.IsCancel() {
+		return input
+	}
+	return fallback(ctx)
+}
@@ -0,0 +1,92 @@
+package solo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type memoEntry[Out any] struct {
+	done      chan struct{}
+	value     Out
+	err       error
+	expiresAt time.Time
+}
+
+// Memoize wraps a Try function with a concurrent cache keyed by keyFn.
+// Only successful values are cached; errors are returned to every caller
+// waiting on the same in-flight call and then evicted so the next call
+// executes again. A panic in onTryExecute is passed on to its caller, the
+// waiting callers get a *rop.PanicError. A non-positive ttl keeps cached values forever.
+func Memoize[In any, K comparable, Out any](
+	onTryExecute func(ctx context.Context, r In) (Out, error),
+	keyFn func(in In) K,
+	ttl time.Duration) func(ctx context.Context, r In) (Out, error) {
+
+	var mu sync.Mutex
+	cache := make(map[K]*memoEntry[Out])
+
+	return func(ctx context.Context, r In) (Out, error) {
+		key := keyFn(r)
+
+		mu.Lock()
+		entry, ok := cache[key]
+		if ok && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+			select {
+			case <-entry.done:
+				delete(cache, key)
+				ok = false
+			default:
+			}
+		}
+
+		if ok {
+			mu.Unlock()
+
+			select {
+			case <-entry.done:
+				return entry.value, entry.err
+			case <-ctx.Done():
+				var zero Out
+				return zero, ctx.Err()
+			}
+		}
+
+		entry = &memoEntry[Out]{done: make(chan struct{})}
+		cache[key] = entry
+		mu.Unlock()
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// onTryExecute panicked: release the waiters and evict the key
+			v := recover()
+			entry.err = rop.NewPanicError(v)
+			mu.Lock()
+			delete(cache, key)
+			mu.Unlock()
+			close(entry.done)
+			if v != nil {
+				panic(v)
+			}
+		}()
+
+		entry.value, entry.err = onTryExecute(ctx, r)
+		completed = true
+
+		mu.Lock()
+		if entry.err != nil {
+			delete(cache, key)
+		} else if ttl > 0 {
+			entry.expiresAt = time.Now().Add(ttl)
+		}
+		mu.Unlock()
+
+		close(entry.done)
+		return entry.value, entry.err
+	}
+}
@@ -198,6 +198,11 @@ func FailOnError[T any](ctx context.Context, input rop.Result[T],
 	return input
 }
 
+func TryTee[T any](ctx context.Context, input rop.Result[T],
+	sideEffect func(ctx context.Context, r T) error) rop.Result[T] {
+	return FailOnError(ctx, input, sideEffect)
+}
+
 func Finally[In, Out any](ctx context.Context, input rop.Result[In],
 	onSuccess func(ctx context.Context, r In) Out,
 	onError func(ctx context.Context, err error) Out,
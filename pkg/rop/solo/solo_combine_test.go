@@ -0,0 +1,73 @@
+package solo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestAll_Success(t *testing.T) {
+	t.Parallel()
+
+	res := All(rop.Success(1), rop.Success(2), rop.Success(3))
+	if !res.IsSuccess() || len(res.Result()) != 3 || res.Result()[2] != 3 {
+		t.Fatalf("expected success [1 2 3], got success=%v val=%v err=%v", res.IsSuccess(), res.Result(), res.Err())
+	}
+
+	empty := All[int]()
+	if !empty.IsSuccess() || len(empty.Result()) != 0 {
+		t.Fatalf("expected empty success, got success=%v val=%v", empty.IsSuccess(), empty.Result())
+	}
+}
+
+func TestAll_JoinsFailures(t *testing.T) {
+	t.Parallel()
+
+	res := All(rop.Success(1), rop.Fail[int](errors.New("a")), rop.Fail[int](errors.New("b")))
+	if res.IsSuccess() || res.IsCancel() {
+		t.Fatalf("expected failure, got success=%v cancel=%v", res.IsSuccess(), res.IsCancel())
+	}
+	errs := rop.GetErrors(res.Err())
+	if len(errs) != 2 || errs[0].Error() != "a" || errs[1].Error() != "b" {
+		t.Fatalf("expected joined errors [a b], got %v", errs)
+	}
+}
+
+func TestAll_CancelWins(t *testing.T) {
+	t.Parallel()
+
+	res := All(rop.Fail[int](errors.New("a")), rop.Cancel[int](errors.New("c")))
+	if !res.IsCancel() {
+		t.Fatalf("expected cancel, got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+}
+
+func TestAny_FirstSuccessWins(t *testing.T) {
+	t.Parallel()
+
+	res := Any(rop.Fail[string](errors.New("a")), rop.Success("x"), rop.Success("y"))
+	if !res.IsSuccess() || res.Result() != "x" {
+		t.Fatalf("expected success 'x', got success=%v val=%v err=%v", res.IsSuccess(), res.Result(), res.Err())
+	}
+}
+
+func TestAny_NoSuccess(t *testing.T) {
+	t.Parallel()
+
+	res := Any(rop.Fail[int](errors.New("a")), rop.Cancel[int](errors.New("c")))
+	if res.IsSuccess() || res.IsCancel() || len(rop.GetErrors(res.Err())) != 2 {
+		t.Fatalf("expected failure with 2 errors, got success=%v cancel=%v err=%v",
+			res.IsSuccess(), res.IsCancel(), res.Err())
+	}
+
+	canceled := Any(rop.Cancel[int](errors.New("c1")), rop.Cancel[int](errors.New("c2")))
+	if !canceled.IsCancel() {
+		t.Fatalf("expected cancel when all inputs are canceled, got err=%v", canceled.Err())
+	}
+
+	empty := Any[int]()
+	if !errors.Is(empty.Err(), ErrNoResults) {
+		t.Fatalf("expected ErrNoResults, got %v", empty.Err())
+	}
+}
@@ -0,0 +1,65 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestCompose3_SuccessPath(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	step := Compose3(
+		TryStep(func(ctx context.Context, s string) (int, error) { return strconv.Atoi(s) }),
+		MapStep(func(ctx context.Context, n int) int { return n * 2 }),
+		SwitchStep(func(ctx context.Context, n int) rop.Result[string] {
+			return rop.Success("n=" + strconv.Itoa(n))
+		}),
+	)
+
+	res := step(ctx, rop.Success("21"))
+	if !res.IsSuccess() || res.Result() != "n=42" {
+		t.Fatalf("expected success 'n=42', got success=%v val=%v err=%v", res.IsSuccess(), res.Result(), res.Err())
+	}
+}
+
+func TestCompose2_ShortCircuitsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+	step := Compose2(
+		TryStep(func(ctx context.Context, s string) (int, error) { return 0, errors.New("parse") }),
+		MapStep(func(ctx context.Context, n int) int { called = true; return n }),
+	)
+
+	res := step(ctx, rop.Success("x"))
+	if res.IsSuccess() || res.Err().Error() != "parse" {
+		t.Fatalf("expected failure 'parse', got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+	if called {
+		t.Fatalf("second step must not run after failure")
+	}
+}
+
+func TestCompose5_PropagatesCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inc := MapStep(func(ctx context.Context, n int) int { return n + 1 })
+	step := Compose5(inc, inc, inc, inc, inc)
+
+	res := step(ctx, rop.Cancel[int](errors.New("stop")))
+	if !res.IsCancel() || res.Err().Error() != "stop" {
+		t.Fatalf("expected cancel 'stop', got cancel=%v err=%v", res.IsCancel(), res.Err())
+	}
+
+	ok := step(ctx, rop.Success(0))
+	if !ok.IsSuccess() || ok.Result() != 5 {
+		t.Fatalf("expected success 5, got success=%v val=%v", ok.IsSuccess(), ok.Result())
+	}
+}
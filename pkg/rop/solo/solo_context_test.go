@@ -0,0 +1,57 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestFromContext_LiveContextPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	res := FromContext(context.Background(), rop.Success(3))
+	if !res.IsSuccess() || res.Result() != 3 {
+		t.Fatalf("expected success 3, got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+}
+
+func TestFromContext_CancelCause(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("shutdown")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	res := FromContext(ctx, rop.Success(3))
+	if !res.IsCancel() || !errors.Is(res.Err(), cause) {
+		t.Fatalf("expected cancel with cause, got cancel=%v err=%v", res.IsCancel(), res.Err())
+	}
+}
+
+func TestFromContext_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	res := FromContext(ctx, rop.Success("x"))
+	if !res.IsCancel() || !errors.Is(res.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected cancel with deadline exceeded, got cancel=%v err=%v", res.IsCancel(), res.Err())
+	}
+}
+
+func TestFromContext_FailureKeepsError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := FromContext(ctx, rop.Fail[int](errors.New("boom")))
+	if res.IsCancel() || res.Err().Error() != "boom" {
+		t.Fatalf("expected original failure, got cancel=%v err=%v", res.IsCancel(), res.Err())
+	}
+}
@@ -0,0 +1,60 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestLazy_EvaluatedOnce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	force := Lazy(func(ctx context.Context) rop.Result[int] {
+		calls++
+		return rop.Success(9)
+	})
+
+	if calls != 0 {
+		t.Fatalf("lazy must not evaluate before it is forced")
+	}
+	for i := 0; i < 3; i++ {
+		if res := force(ctx); !res.IsSuccess() || res.Result() != 9 {
+			t.Fatalf("expected success 9, got success=%v val=%v", res.IsSuccess(), res.Result())
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single evaluation, got %d", calls)
+	}
+}
+
+func TestOrElse_FallbackOnlyOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	calls := 0
+	fallback := Lazy(func(ctx context.Context) rop.Result[string] {
+		calls++
+		return rop.Success("fallback")
+	})
+
+	if res := OrElse(ctx, rop.Success("primary"), fallback); res.Result() != "primary" {
+		t.Fatalf("expected primary, got %v", res.Result())
+	}
+	if res := OrElse(ctx, rop.Cancel[string](errors.New("stop")), fallback); !res.IsCancel() {
+		t.Fatalf("expected cancel to propagate, got %v", res.Err())
+	}
+	if calls != 0 {
+		t.Fatalf("fallback must not be evaluated for success or cancel, got %d calls", calls)
+	}
+
+	if res := OrElse(ctx, rop.Fail[string](errors.New("boom")), fallback); !res.IsSuccess() || res.Result() != "fallback" {
+		t.Fatalf("expected fallback success, got success=%v val=%v", res.IsSuccess(), res.Result())
+	}
+	if calls != 1 {
+		t.Fatalf("expected fallback to be evaluated once, got %d", calls)
+	}
+}
@@ -0,0 +1,159 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestMemoize_CachesSuccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls int32
+	square := Memoize(func(ctx context.Context, in int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return in * in, nil
+	}, func(in int) int { return in }, 0)
+
+	for i := 0; i < 3; i++ {
+		res := Try[int, int](ctx, rop.Success(4), square)
+		if !res.IsSuccess() || res.Result() != 16 {
+			t.Fatalf("expected success 16, got success=%v val=%v err=%v", res.IsSuccess(), res.Result(), res.Err())
+		}
+	}
+	Try[int, int](ctx, rop.Success(5), square)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 executions, got %d", got)
+	}
+}
+
+func TestMemoize_ErrorsAreNotCached(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls int32
+	f := Memoize(func(ctx context.Context, in int) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return 0, errors.New("transient")
+		}
+		return in, nil
+	}, func(in int) int { return in }, 0)
+
+	first := Try[int, int](ctx, rop.Success(1), f)
+	if first.IsSuccess() || first.Err().Error() != "transient" {
+		t.Fatalf("expected failure 'transient', got success=%v err=%v", first.IsSuccess(), first.Err())
+	}
+
+	second := Try[int, int](ctx, rop.Success(1), f)
+	if !second.IsSuccess() || second.Result() != 1 {
+		t.Fatalf("expected success 1, got success=%v err=%v", second.IsSuccess(), second.Err())
+	}
+}
+
+func TestMemoize_TTLExpiry(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls int32
+	f := Memoize(func(ctx context.Context, in string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return len(in), nil
+	}, func(in string) string { return in }, 20*time.Millisecond)
+
+	_, _ = f(ctx, "abc")
+	_, _ = f(ctx, "abc")
+	time.Sleep(40 * time.Millisecond)
+	_, _ = f(ctx, "abc")
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 executions after expiry, got %d", got)
+	}
+}
+
+func TestMemoize_ConcurrentCallsShareExecution(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var calls int32
+	release := make(chan struct{})
+	f := Memoize(func(ctx context.Context, in int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return in + 1, nil
+	}, func(in int) int { return in }, 0)
+
+	wg := &sync.WaitGroup{}
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = f(ctx, 41)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected a single execution, got %d", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result %d: expected 42, got %d", i, v)
+		}
+	}
+}
+
+func TestMemoize_PanicDoesNotPoisonKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := Memoize(func(ctx context.Context, in int) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+			<-release
+			panic("fetch failed")
+		}
+		return in, nil
+	}, func(in int) int { return in }, 0)
+
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() { panicked <- recover() }()
+		_, _ = fetch(ctx, 1)
+	}()
+
+	<-started
+	waited := make(chan error, 1)
+	go func() {
+		_, err := fetch(ctx, 1)
+		waited <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	if p := <-panicked; p != "fetch failed" {
+		t.Fatalf("expected the panic passed on to the caller, got %v", p)
+	}
+	var panicErr *rop.PanicError
+	if err := <-waited; !errors.As(err, &panicErr) {
+		t.Fatalf("expected the waiting caller to get a *rop.PanicError, got %v", err)
+	}
+	if v, err := fetch(ctx, 1); err != nil || v != 1 {
+		t.Fatalf("expected the key to execute again, got %v and %v", v, err)
+	}
+}
@@ -0,0 +1,69 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestTryTee_SuccessKeepsValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	audited := 0
+	res := TryTee(ctx, rop.Success(8), func(ctx context.Context, r int) error {
+		audited = r
+		return nil
+	})
+
+	if !res.IsSuccess() || res.Result() != 8 {
+		t.Fatalf("expected success 8, got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+	if audited != 8 {
+		t.Fatalf("expected side effect to observe 8, got %d", audited)
+	}
+}
+
+func TestTryTee_ErrorBecomesFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	res := TryTee(ctx, rop.Success(8), func(ctx context.Context, r int) error {
+		return errors.New("audit failed")
+	})
+
+	if res.IsSuccess() || res.IsCancel() || res.Err().Error() != "audit failed" {
+		t.Fatalf("expected failure 'audit failed', got success=%v cancel=%v err=%v",
+			res.IsSuccess(), res.IsCancel(), res.Err())
+	}
+}
+
+func TestTryTee_CancellationErrorBecomesCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	res := TryTee(ctx, rop.Success(8), func(ctx context.Context, r int) error {
+		return context.DeadlineExceeded
+	})
+
+	if !res.IsCancel() {
+		t.Fatalf("expected cancel, got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+}
+
+func TestTryTee_SkipsOnFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	called := false
+	res := TryTee(ctx, rop.Fail[int](errors.New("prev")), func(ctx context.Context, r int) error {
+		called = true
+		return nil
+	})
+
+	if called || res.Err().Error() != "prev" {
+		t.Fatalf("expected side effect to be skipped, called=%v err=%v", called, res.Err())
+	}
+}
@@ -0,0 +1,89 @@
+package solo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestValidateAllNamed_AttributesErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	v := -3
+	res := ValidateAllNamed(ctx, rop.Success(v), false,
+		NamedValidator[int]{Name: "sign", Validate: validateNonNegative(v)},
+		NamedValidator[int]{Name: "parity", Validate: validateEven(v)},
+	)
+
+	if res.IsSuccess() {
+		t.Fatalf("expected failure, got success")
+	}
+
+	verrs := ValidationErrors(res.Err())
+	if len(verrs) != 2 {
+		t.Fatalf("expected 2 validation errors, got %d (%v)", len(verrs), res.Err())
+	}
+	if verrs[0].Name != "sign" || verrs[0].Message() != "negative" ||
+		verrs[1].Name != "parity" || verrs[1].Message() != "odd" {
+		t.Fatalf("unexpected validation errors: %v, %v", verrs[0], verrs[1])
+	}
+	if verrs[0].Error() != "sign: negative" {
+		t.Fatalf("expected 'sign: negative', got %q", verrs[0].Error())
+	}
+}
+
+func TestValidateAllNamed_Success(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	res := ValidateAllNamed(ctx, rop.Success(4), true,
+		NamedValidator[int]{Name: "sign", Validate: validateNonNegative(4)},
+		NamedValidator[int]{Name: "parity", Validate: validateEven(4)},
+	)
+
+	if !res.IsSuccess() || res.Result() != 4 {
+		t.Fatalf("expected success 4, got success=%v err=%v", res.IsSuccess(), res.Err())
+	}
+}
+
+func TestValidationErrors_IgnoresPlainErrors(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Join(errors.New("plain"), &ValidationError{Name: "n", Err: errors.New("m")})
+	verrs := ValidationErrors(err)
+	if len(verrs) != 1 || verrs[0].Name != "n" {
+		t.Fatalf("expected a single named error, got %v", verrs)
+	}
+	if len(ValidationErrors(nil)) != 0 {
+		t.Fatalf("expected no errors for nil")
+	}
+}
+
+func TestValidateAllNamed_SameSentinel(t *testing.T) {
+	t.Parallel()
+
+	errRequired := errors.New("required")
+	required := func(ctx context.Context, in rop.Result[string]) rop.Result[string] {
+		return rop.Fail[string](errRequired)
+	}
+	present := func(ctx context.Context, in rop.Result[string]) rop.Result[string] {
+		return in
+	}
+
+	res := ValidateAllNamed(context.Background(), rop.Success(""), false,
+		NamedValidator[string]{Name: "name", Validate: required},
+		NamedValidator[string]{Name: "id", Validate: present},
+		NamedValidator[string]{Name: "email", Validate: required},
+	)
+
+	verrs := ValidationErrors(res.Err())
+	if len(verrs) != 2 || verrs[0].Name != "name" || verrs[1].Name != "email" {
+		t.Fatalf("expected name and email attributed, got %v", res.Err())
+	}
+	if !errors.Is(res.Err(), errRequired) {
+		t.Fatalf("expected the sentinel kept, got %v", res.Err())
+	}
+}
@@ -0,0 +1,75 @@
+package solo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type NamedValidator[T any] struct {
+	Name     string
+	Validate func(ctx context.Context, in rop.Result[T]) rop.Result[T]
+}
+
+// ValidationError attributes a validation failure to the validator that produced it.
+type ValidationError struct {
+	Name string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) Message() string {
+	return e.Err.Error()
+}
+
+// ValidateAllNamed behaves like ValidateAll, but every failure is wrapped in a
+// *ValidationError carrying the validator name (see ValidationErrors). Each
+// validator checks the input itself, so its failure is told apart from those
+// of the validators before it even when they return the same error.
+func ValidateAllNamed[T any](
+	ctx context.Context,
+	input rop.Result[T],
+	breakOnError bool, // exit on first error
+	validators ...NamedValidator[T]) rop.Result[T] {
+
+	if !input.IsSuccess() {
+		return input
+	}
+
+	inputsF := make([]func(ctx context.Context, in rop.Result[T]) rop.Result[T], 0, len(validators))
+	for _, v := range validators {
+		inputsF = append(inputsF, func(ctx context.Context, _ rop.Result[T]) rop.Result[T] {
+			res := v.Validate(ctx, input)
+			switch {
+			case res.IsCancel():
+				return res
+			case res.IsFailure():
+				return rop.Fail[T](&ValidationError{Name: v.Name, Err: res.Err()})
+			}
+			// the failures so far are already joined by ValidateAll
+			return input
+		})
+	}
+
+	return ValidateAll(ctx, input, breakOnError, inputsF...)
+}
+
+// ValidationErrors extracts the named validation errors joined into err.
+func ValidationErrors(err error) []*ValidationError {
+	res := make([]*ValidationError, 0)
+	for _, e := range rop.GetErrors(err) {
+		var ve *ValidationError
+		if errors.As(e, &ve) {
+			res = append(res, ve)
+		}
+	}
+	return res
+}
@@ -0,0 +1,27 @@
+package tiny
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// StartAccumulating creates a Chain whose failed steps don't short-circuit:
+// each failure is collected and the chain continues with the last good value.
+// Result reports the collected failures joined together. Cancels still stop the chain.
+func StartAccumulating[T any](ctx context.Context, r rop.Result[T]) Chain[T] {
+	c := Chain[T]{ctx: ctx, accumulate: true}
+	return c.next(r)
+}
+
+// next advances the chain to res, collecting failures instead of stopping
+// on them when the chain is accumulating.
+func (c Chain[T]) next(res rop.Result[T]) Chain[T] {
+	if c.accumulate && res.IsFailure() && !res.IsCancel() {
+		c.errs = append(slices.Clip(c.errs), res.Err())
+		return c
+	}
+	c.res = res
+	return c
+}
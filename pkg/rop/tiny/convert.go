@@ -0,0 +1,42 @@
+package tiny
+
+import (
+	"context"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+// Then composes a type-changing function that returns rop.Result[U].
+// A processed result stays processed but cannot carry its value over to U,
+// so it continues with the zero U value.
+func Then[T, U any](c Chain[T], onSuccess func(ctx context.Context, t T) rop.Result[U]) Chain[U] {
+	if c.res.IsFailure() || c.res.IsCancel() || c.res.IsProcessed() {
+		return convert(c, stopped[T, U](c.res))
+	}
+	return convert(c, onSuccess(c.ctx, c.res.Result()))
+}
+
+// Map transforms the successful value to a value of another type
+func Map[T, U any](c Chain[T], onSuccess func(ctx context.Context, t T) U) Chain[U] {
+	if c.res.IsFailure() || c.res.IsCancel() || c.res.IsProcessed() {
+		return convert(c, stopped[T, U](c.res))
+	}
+	return convert(c, rop.Success(onSuccess(c.ctx, c.res.Result())))
+}
+
+func stopped[T, U any](r rop.Result[T]) rop.Result[U] {
+	switch {
+	case r.IsCancel():
+		return rop.Cancel[U](r.Err())
+	case r.IsFailure():
+		return rop.Fail[U](r.Err())
+	default:
+		var zero U
+		return rop.SuccessAndProcessed(zero)
+	}
+}
+
+// convert carries ctx and accumulating state over to a chain of another type.
+func convert[T, U any](c Chain[T], res rop.Result[U]) Chain[U] {
+	return Chain[U]{ctx: c.ctx, accumulate: c.accumulate, errs: c.errs}.next(res)
+}
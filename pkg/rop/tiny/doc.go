@@ -3,15 +3,23 @@
 //
 // It parallels the chain package but keeps API surface very small:
 //   - Start/FromValue: create a Chain from a Result or value
+//   - StartAccumulating: collect failures as joined errors instead of
+//     short-circuiting, continuing with the last good value
 //   - Then/ThenTry: compose result-returning or error-returning functions
 //     (ThenTry converts DeadlineExceeded to a cancel result)
+//   - ThenTryWithin: ThenTry with a per-step timeout that cancels on expiry
 //   - RepeatUntil: loop until a predicate signals stop
 //     (composes a nested Chain on each iteration)
 //   - While: loop while a predicate holds
 //     (rebuilds the Chain from the current value each iteration)
 //
 // - Map: transform the successful value to a new Result
-// - Ensure: trigger side effects for success, failure, or processed results
+// - Then/Map (package-level): type-changing variants, Chain[T] -> Chain[U]
+// - Validate: fail the chain when a (bool, errMsg) validator rejects the value
+// - Filter: fail the chain with errMsg when a predicate does not hold
+// - Recover: turn a failure back into a success value
+// - Ensure: trigger side effects for success, failure, processed, or canceled
+//   results (onCancel also fires for ThenTry's DeadlineExceeded conversion)
 // - Finally: reduce to a concrete value via handlers
 //
 // Tiny is ideal for small services or tests where lightweight synchronous
@@ -0,0 +1,35 @@
+package tiny
+
+import (
+	"context"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+// ThenTryWithin is ThenTry with the call bounded by d. If d elapses before try
+// returns, the chain yields a cancel result carrying context.DeadlineExceeded.
+func (c Chain[T]) ThenTryWithin(d time.Duration, try func(ctx context.Context, t T) (T, error)) Chain[T] {
+	return c.ThenTry(func(ctx context.Context, t T) (T, error) {
+		stepCtx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+
+		type outcome struct {
+			value T
+			err   error
+		}
+		done := make(chan outcome, 1)
+		core.Go(ctx, func() {
+			v, err := try(stepCtx, t)
+			done <- outcome{value: v, err: err}
+		})
+
+		select {
+		case o := <-done:
+			return o.value, o.err
+		case <-stepCtx.Done():
+			var zero T
+			return zero, stepCtx.Err()
+		}
+	})
+}
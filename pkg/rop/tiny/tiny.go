@@ -2,14 +2,17 @@ package tiny
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ib-77/rop3/pkg/rop"
 	"github.com/ib-77/rop3/pkg/rop/solo"
 )
 
 type Chain[T any] struct {
-	ctx context.Context
-	res rop.Result[T]
+	ctx        context.Context
+	res        rop.Result[T]
+	accumulate bool
+	errs       []error
 }
 
 func Start[T any](ctx context.Context, r rop.Result[T]) Chain[T] {
@@ -20,7 +23,16 @@ func FromValue[T any](ctx context.Context, v T) Chain[T] {
 	return Start(ctx, rop.Success(v))
 }
 
+func (c Chain[T]) Context() context.Context {
+	return c.ctx
+}
+
+// Result returns the chain result; in accumulating mode any collected
+// failures are reported as a single joined failure.
 func (c Chain[T]) Result() rop.Result[T] {
+	if len(c.errs) > 0 && !c.res.IsCancel() {
+		return rop.Fail[T](errors.Join(c.errs...))
+	}
 	return c.res
 }
 
@@ -29,7 +41,7 @@ func (c Chain[T]) Then(onSuccess func(ctx context.Context, t T) rop.Result[T]) C
 	if c.res.IsFailure() || c.res.IsCancel() || c.res.IsProcessed() {
 		return c
 	}
-	return Chain[T]{ctx: c.ctx, res: onSuccess(c.ctx, c.res.Result())}
+	return c.next(onSuccess(c.ctx, c.res.Result()))
 }
 
 //func (c Chain[T]) RepeatUntil(onSuccess func(ctx context.Context, t T) rop.Result[T],
@@ -90,11 +102,11 @@ func (c Chain[T]) ThenTry(try func(ctx context.Context, t T) (T, error)) Chain[T
 	u, err := try(c.ctx, c.res.Result())
 	if err != nil {
 		if rop.IsCancellationError(err) {
-			return Chain[T]{ctx: c.ctx, res: rop.Cancel[T](err)}
+			return c.next(rop.Cancel[T](err))
 		}
-		return Chain[T]{ctx: c.ctx, res: rop.Fail[T](err)}
+		return c.next(rop.Fail[T](err))
 	}
-	return Chain[T]{ctx: c.ctx, res: rop.Success(u)}
+	return c.next(rop.Success(u))
 }
 
 // Map transforms the successful value to a new value
@@ -103,36 +115,68 @@ func (c Chain[T]) Map(onSuccess func(ctx context.Context, t T) T) Chain[T] {
 		return c
 	}
 
-	return Chain[T]{ctx: c.ctx, res: rop.Success(onSuccess(c.ctx, c.res.Result()))}
+	return c.next(rop.Success(onSuccess(c.ctx, c.res.Result())))
+}
+
+// Validate fails the chain with errMsg when validate reports the value as invalid,
+// delegating to solo.AndValidate
+func (c Chain[T]) Validate(validate func(ctx context.Context, t T) (bool, string)) Chain[T] {
+	if c.res.IsFailure() || c.res.IsCancel() || c.res.IsProcessed() {
+		return c
+	}
+	return c.next(solo.AndValidate(c.ctx, c.res, validate))
+}
+
+// Filter fails the chain with errMsg when pred does not hold for the value
+func (c Chain[T]) Filter(pred func(ctx context.Context, t T) bool, errMsg string) Chain[T] {
+	return c.Validate(func(ctx context.Context, t T) (bool, string) {
+		return pred(ctx, t), errMsg
+	})
+}
+
+// Recover turns a failure into a success when onFailure returns (value, true).
+// Cancels are not recovered.
+func (c Chain[T]) Recover(onFailure func(ctx context.Context, err error) (T, bool)) Chain[T] {
+	res := c.Result()
+	if !res.IsFailure() || res.IsCancel() {
+		return c
+	}
+
+	if v, ok := onFailure(c.ctx, res.Err()); ok {
+		return Chain[T]{ctx: c.ctx, res: rop.Success(v), accumulate: c.accumulate}
+	}
+	return c
 }
 
-// Ensure triggers side effects for success/failure without changing the result
+// Ensure triggers side effects for success/failure/processed/cancel without changing the result.
+// Cancels (e.g. ThenTry's DeadlineExceeded conversion) only reach onCancel, never onFailure.
 func (c Chain[T]) Ensure(onSuccess func(context.Context, T), onFailure func(context.Context, error),
 	onProcessed func(context.Context, T), onCancel func(context.Context, error)) Chain[T] {
 
-	if c.res.IsCancel() {
+	res := c.Result()
+	if res.IsCancel() {
 		if onCancel != nil {
-			onCancel(c.ctx, c.res.Err())
+			onCancel(c.ctx, res.Err())
 		}
 		return c
 	}
 
-	if c.res.IsFailure() {
+	if res.IsFailure() {
 		if onFailure != nil {
-			onFailure(c.ctx, c.res.Err())
+			onFailure(c.ctx, res.Err())
 		}
 		return c
 	}
 
-	if c.res.IsProcessed() {
+	if res.IsProcessed() {
 		if onProcessed != nil {
-			onProcessed(c.ctx, c.res.Result())
+			onProcessed(c.ctx, res.Result())
 		}
 		return c
 	}
 
 	if onSuccess != nil {
-		onSuccess(c.ctx, c.res.Result())
+		onSuccess(c.ctx, res.Result())
 	}
 	return c
 }
@@ -143,5 +187,5 @@ func (c Chain[T]) Finally(
 	onFailure func(context.Context, error) T,
 	onCancel func(context.Context, error) T,
 ) T {
-	return solo.Finally(c.ctx, c.res, onSuccess, onFailure, onCancel)
+	return solo.Finally(c.ctx, c.Result(), onSuccess, onFailure, onCancel)
 }
@@ -0,0 +1,105 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+type form struct {
+	Name  string
+	Email string
+	Age   int
+}
+
+func TestStartAccumulating_CollectsFailures(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var validated int
+	out := StartAccumulating(ctx, rop.Success(form{Age: 200})).
+		Validate(func(ctx context.Context, f form) (bool, string) {
+			validated++
+			return f.Name != "", "name is required"
+		}).
+		Validate(func(ctx context.Context, f form) (bool, string) {
+			validated++
+			return strings.Contains(f.Email, "@"), "email is invalid"
+		}).
+		Validate(func(ctx context.Context, f form) (bool, string) {
+			validated++
+			return f.Age < 150, "age is out of range"
+		}).
+		Result()
+
+	if validated != 3 {
+		t.Fatalf("expected every validation to run, got %d", validated)
+	}
+	if out.IsSuccess() {
+		t.Fatalf("expected accumulated failure")
+	}
+	if errs := rop.GetErrors(out.Err()); len(errs) != 3 {
+		t.Fatalf("expected 3 joined errors, got %d: %v", len(errs), out.Err())
+	}
+}
+
+func TestStartAccumulating_ContinuesWithLastGoodValue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var seen []int
+	c := StartAccumulating(ctx, rop.Success(1)).
+		Map(func(ctx context.Context, v int) int { return v + 1 }).
+		Then(func(ctx context.Context, v int) rop.Result[int] { return rop.Fail[int](errors.New("boom")) }).
+		Map(func(ctx context.Context, v int) int {
+			seen = append(seen, v)
+			return v * 10
+		})
+
+	if len(seen) != 1 || seen[0] != 2 {
+		t.Fatalf("expected step after failure to see last good value 2, got %v", seen)
+	}
+	out := c.Result()
+	if out.IsSuccess() || out.Err().Error() != "boom" {
+		t.Fatalf("expected failure 'boom', got: success=%v, err=%v", out.IsSuccess(), out.Err())
+	}
+
+	recovered := c.Recover(func(ctx context.Context, err error) (int, bool) { return 0, true }).Result()
+	if !recovered.IsSuccess() {
+		t.Fatalf("expected Recover to clear accumulated failures, got err=%v", recovered.Err())
+	}
+}
+
+func TestStartAccumulating_CancelStops(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	called := false
+	out := StartAccumulating(ctx, rop.Success(1)).
+		ThenTry(func(ctx context.Context, v int) (int, error) { return 0, context.DeadlineExceeded }).
+		Map(func(ctx context.Context, v int) int {
+			called = true
+			return v
+		}).
+		Result()
+
+	if called || !out.IsCancel() {
+		t.Fatalf("expected cancel to stop the chain, called=%v cancel=%v", called, out.IsCancel())
+	}
+}
+
+func TestStartAccumulating_ThroughTypeChange(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	c := StartAccumulating(ctx, rop.Success(2)).
+		Validate(func(ctx context.Context, v int) (bool, string) { return v > 5, "too small" })
+	out := Map(c, func(ctx context.Context, v int) string { return strings.Repeat("x", v) }).Result()
+
+	if out.IsSuccess() || out.Err().Error() != "too small" {
+		t.Fatalf("expected accumulated failure to survive Map, got: success=%v, err=%v", out.IsSuccess(), out.Err())
+	}
+}
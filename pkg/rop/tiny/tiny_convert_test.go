@@ -0,0 +1,55 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func TestPackageThenAndMap_ChangeType(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	s := Map(FromValue(ctx, 21), func(ctx context.Context, v int) string { return strconv.Itoa(v * 2) })
+	out := Then(s, func(ctx context.Context, v string) rop.Result[[]byte] { return rop.Success([]byte(v)) }).Result()
+
+	if !out.IsSuccess() || string(out.Result()) != "42" {
+		t.Fatalf("expected success '42', got: success=%v, val=%v, err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
+
+func TestPackageThen_ShortCircuit(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	called := false
+	fn := func(ctx context.Context, v int) rop.Result[string] {
+		called = true
+		return rop.Success("x")
+	}
+
+	failed := Then(Start(ctx, rop.Fail[int](errors.New("boom"))), fn).Result()
+	if failed.IsSuccess() || failed.Err().Error() != "boom" {
+		t.Fatalf("expected failure 'boom', got: success=%v, err=%v", failed.IsSuccess(), failed.Err())
+	}
+
+	canceled := Then(Start(ctx, rop.Cancel[int](errors.New("stop"))), fn).Result()
+	if !canceled.IsCancel() {
+		t.Fatalf("expected cancel, got: err=%v", canceled.Err())
+	}
+
+	processed := Map(Start(ctx, rop.SuccessAndProcessed(1)), func(ctx context.Context, v int) string {
+		called = true
+		return "x"
+	}).Result()
+	if !processed.IsProcessed() || !processed.IsSuccess() {
+		t.Fatalf("expected processed success, got: success=%v, processed=%v", processed.IsSuccess(), processed.IsProcessed())
+	}
+
+	if called {
+		t.Fatalf("onSuccess must not be called for failure, cancel or processed results")
+	}
+}
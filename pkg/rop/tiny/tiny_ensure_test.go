@@ -0,0 +1,23 @@
+package tiny
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEnsure_CancelFromThenTryDeadline(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var failures, cancels int
+	FromValue(ctx, 1).
+		ThenTry(func(ctx context.Context, v int) (int, error) { return 0, context.DeadlineExceeded }).
+		Ensure(nil,
+			func(ctx context.Context, err error) { failures++ },
+			nil,
+			func(ctx context.Context, err error) { cancels++ })
+
+	if cancels != 1 || failures != 0 {
+		t.Fatalf("expected only onCancel to fire, got cancels=%d failures=%d", cancels, failures)
+	}
+}
@@ -0,0 +1,51 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+var errNotFound = errors.New("not found")
+
+func defaultOnNotFound(ctx context.Context, err error) (int, bool) {
+	if errors.Is(err, errNotFound) {
+		return -1, true
+	}
+	return 0, false
+}
+
+func TestRecover_Fallback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	out := Start(ctx, rop.Fail[int](errNotFound)).Recover(defaultOnNotFound).Result()
+	if !out.IsSuccess() || out.Result() != -1 {
+		t.Fatalf("expected recovered success -1, got: success=%v, val=%v, err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+}
+
+func TestRecover_NotRecovered(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	other := Start(ctx, rop.Fail[int](errors.New("db"))).Recover(defaultOnNotFound).Result()
+	if other.IsSuccess() || other.Err().Error() != "db" {
+		t.Fatalf("expected unchanged failure 'db', got: success=%v, err=%v", other.IsSuccess(), other.Err())
+	}
+
+	canceled := Start(ctx, rop.Cancel[int](errNotFound)).Recover(defaultOnNotFound).Result()
+	if !canceled.IsCancel() {
+		t.Fatalf("cancel must not be recovered, got: success=%v", canceled.IsSuccess())
+	}
+
+	ok := FromValue(ctx, 5).Recover(func(ctx context.Context, err error) (int, bool) {
+		t.Fatalf("recover must not be called on success")
+		return 0, false
+	}).Result()
+	if ok.Result() != 5 {
+		t.Fatalf("expected unchanged 5, got %v", ok.Result())
+	}
+}
@@ -0,0 +1,73 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+)
+
+func TestThenTryWithin_Expires(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	out := FromValue(ctx, 1).
+		ThenTryWithin(10*time.Millisecond, func(ctx context.Context, v int) (int, error) {
+			select {
+			case <-time.After(time.Second):
+				return v + 1, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}).
+		Result()
+
+	if !out.IsCancel() || !errors.Is(out.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected cancel with DeadlineExceeded, got: cancel=%v, err=%v", out.IsCancel(), out.Err())
+	}
+}
+
+func TestThenTryWithin_CompletesInTime(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	out := FromValue(ctx, 1).
+		ThenTryWithin(time.Second, func(ctx context.Context, v int) (int, error) { return v + 1, nil }).
+		Result()
+	if !out.IsSuccess() || out.Result() != 2 {
+		t.Fatalf("expected success 2, got: success=%v, val=%v, err=%v", out.IsSuccess(), out.Result(), out.Err())
+	}
+
+	failed := FromValue(ctx, 1).
+		ThenTryWithin(time.Second, func(ctx context.Context, v int) (int, error) { return 0, errors.New("db") }).
+		Result()
+	if failed.IsSuccess() || failed.IsCancel() || failed.Err().Error() != "db" {
+		t.Fatalf("expected failure 'db', got: success=%v, cancel=%v, err=%v", failed.IsSuccess(), failed.IsCancel(), failed.Err())
+	}
+}
+
+func TestThenTryWithin_AbandonedCallInGroup(t *testing.T) {
+	t.Parallel()
+	ctx, group := core.WithGroup(context.Background())
+	release := make(chan struct{})
+
+	out := FromValue(ctx, 1).
+		ThenTryWithin(5*time.Millisecond, func(ctx context.Context, v int) (int, error) {
+			<-release
+			return v, nil
+		}).
+		Result()
+	if !out.IsCancel() {
+		t.Fatalf("expected cancel on timeout, got: err=%v", out.Err())
+	}
+	if err := group.AssertIdle(); err == nil {
+		t.Fatalf("expected the abandoned call to be owned by the group")
+	}
+
+	close(release)
+	if err := group.WaitTimeout(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,66 @@
+package tiny
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+)
+
+func positive(ctx context.Context, v int) (bool, string) {
+	return v > 0, "must be positive"
+}
+
+func TestValidate_PassAndFail(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ok := FromValue(ctx, 3).Validate(positive).Result()
+	if !ok.IsSuccess() || ok.Result() != 3 {
+		t.Fatalf("expected success 3, got: success=%v, val=%v, err=%v", ok.IsSuccess(), ok.Result(), ok.Err())
+	}
+
+	bad := FromValue(ctx, -1).Validate(positive).Result()
+	if bad.IsSuccess() || bad.Err().Error() != "must be positive" {
+		t.Fatalf("expected failure 'must be positive', got: success=%v, err=%v", bad.IsSuccess(), bad.Err())
+	}
+}
+
+func TestValidate_SkipsNonSuccess(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	called := false
+	validate := func(ctx context.Context, v int) (bool, string) {
+		called = true
+		return true, ""
+	}
+
+	Start(ctx, rop.Fail[int](errors.New("boom"))).Validate(validate)
+	Start(ctx, rop.Cancel[int](errors.New("stop"))).Validate(validate)
+	processed := Start(ctx, rop.SuccessAndProcessed(1)).Validate(validate).Result()
+
+	if called {
+		t.Fatalf("validator must not run for failure, cancel, or processed results")
+	}
+	if !processed.IsProcessed() {
+		t.Fatalf("expected processed result to be kept")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	even := func(ctx context.Context, v int) bool { return v%2 == 0 }
+
+	kept := FromValue(ctx, 4).Filter(even, "odd value").Result()
+	if !kept.IsSuccess() || kept.Result() != 4 {
+		t.Fatalf("expected success 4, got: success=%v, val=%v, err=%v", kept.IsSuccess(), kept.Result(), kept.Err())
+	}
+
+	dropped := FromValue(ctx, 3).Filter(even, "odd value").Result()
+	if dropped.IsSuccess() || dropped.Err().Error() != "odd value" {
+		t.Fatalf("expected failure 'odd value', got: success=%v, err=%v", dropped.IsSuccess(), dropped.Err())
+	}
+}
@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillThenTail_DedupesOverlap(t *testing.T) {
+	ctx := context.Background()
+
+	backfill := core.ToChanManyResults(ctx, []int{1, 2, 3})
+	live := make(chan rop.Result[int])
+	go func() {
+		defer close(live)
+		for _, r := range []rop.Result[int]{rop.Success(2), rop.Success(3),
+			rop.Fail[int](errors.New("bad")), rop.Success(4)} {
+			live <- r
+		}
+	}()
+
+	var values []int
+	failures := 0
+	for _, r := range core.FromChanMany(ctx,
+		core.BackfillThenTail(ctx, backfill, live, func(v int) int { return v }, nil)) {
+		if r.IsSuccess() {
+			values = append(values, r.Result())
+		} else {
+			failures++
+		}
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4}, values)
+	assert.Equal(t, 1, failures)
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/lite"
+	"github.com/ib-77/rop3/pkg/rop/mass"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup_OwnsPipelineGoroutines(t *testing.T) {
+	ctx, group := core.WithGroup(context.Background())
+
+	out := core.FromChanMany(ctx,
+		lite.Finally(ctx,
+			lite.Run(ctx, core.ToChanManyResults(ctx, []int{1, 2, 3}),
+				lite.Map(func(ctx context.Context, r int) int { return r + 1 }), 2),
+			mass.FinallyHandlers[int, int]{
+				OnSuccess: func(ctx context.Context, r int) int { return r },
+				OnError:   func(ctx context.Context, err error) int { return -1 },
+				OnCancel:  func(ctx context.Context, err error) int { return -2 },
+			}))
+
+	assert.ElementsMatch(t, []int{2, 3, 4}, out)
+	assert.NoError(t, group.WaitTimeout(time.Second))
+	assert.NoError(t, group.AssertIdle())
+	assert.Greater(t, group.Total(), int64(0))
+}
+
+func TestGroup_ReportsLeakedGoroutines(t *testing.T) {
+	ctx, group := core.WithGroup(context.Background())
+
+	release := make(chan struct{})
+	core.Go(ctx, func() { <-release })
+
+	assert.Error(t, group.WaitTimeout(10*time.Millisecond))
+	assert.Error(t, group.AssertIdle())
+
+	close(release)
+	group.Wait()
+	assert.NoError(t, group.AssertIdle())
+}
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ib-77/rop3/pkg/rop"
+	"github.com/ib-77/rop3/pkg/rop/core"
+	"github.com/ib-77/rop3/pkg/rop/lite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiftAndLowerChan(t *testing.T) {
+	ctx := context.Background()
+
+	plain := make(chan int)
+	go func() {
+		defer close(plain)
+		for i := 1; i <= 5; i++ {
+			plain <- i
+		}
+	}()
+
+	var failed int32
+	values := core.FromChanMany(ctx,
+		core.LowerChan(ctx,
+			lite.Run(ctx, core.LiftChan(ctx, plain),
+				lite.Switch(func(ctx context.Context, r int) rop.Result[int] {
+					if r%2 == 0 {
+						return rop.Fail[int](errors.New("even"))
+					}
+					return rop.Success(r * 10)
+				}), 1),
+			func(ctx context.Context, r rop.Result[int]) { atomic.AddInt32(&failed, 1) }))
+
+	assert.Equal(t, []int{10, 30, 50}, values)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&failed))
+}